@@ -0,0 +1,397 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jackall3n/render-go"
+	"github.com/jackall3n/terraform-provider-render/internal/tfgen"
+	"github.com/jackall3n/terraform-provider-render/render/api"
+	"github.com/jackall3n/terraform-provider-render/render/models"
+	"github.com/jackall3n/terraform-provider-render/render/utils"
+)
+
+// Clients are the Render API clients of the provider, configured for the same server.
+type Clients struct {
+	Client *render.ClientWithResponses
+	API    *api.Client
+}
+
+// Files are the generated configuration files, keyed by file name.
+type Files map[string][]byte
+
+type exporter struct {
+	clients   Clients
+	config    *tfgen.File
+	imports   *tfgen.File
+	variables *tfgen.File
+	count     int
+	warnings  []string
+}
+
+// Export returns the configuration of every service of an owner, with the `import` blocks
+// to adopt them, and warnings about everything that couldn't be exported.
+//
+// The configuration is written to `services.tf` and the import blocks to `imports.tf`,
+// which can be removed once the resources are imported. The API can't tell secrets apart,
+// so the values of environment variables are never written, they are read from the sensitive
+// input variables declared in `variables.tf`.
+func Export(ctx context.Context, clients Clients, ownerId string) (Files, []string, error) {
+	e := &exporter{
+		clients:   clients,
+		config:    tfgen.NewFile(),
+		imports:   tfgen.NewFile(),
+		variables: tfgen.NewFile(),
+	}
+
+	services, err := e.listServices(ctx, ownerId)
+
+	if err != nil {
+		return nil, e.warnings, fmt.Errorf("failed to list services: %s", err)
+	}
+
+	for _, service := range services {
+		if err := e.service(ctx, *service.Id); err != nil {
+			return nil, e.warnings, fmt.Errorf("services[%s]: %s", *service.Name, err)
+		}
+	}
+
+	files := Files{
+		"services.tf": e.config.Bytes(),
+		"imports.tf":  e.imports.Bytes(),
+	}
+
+	if e.count > 0 {
+		files["variables.tf"] = e.variables.Bytes()
+
+		e.warn("the values of %d environment variables are not exported, set the input variables of variables.tf before planning, e.g. with TF_VAR_<name>", e.count)
+	}
+
+	return files, e.warnings, nil
+}
+
+func (e *exporter) service(ctx context.Context, serviceId string) error {
+	response, err := e.clients.API.GetServiceWithResponse(ctx, serviceId)
+
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode() != http.StatusOK {
+		return fmt.Errorf("%s %s", response.Status(), string(response.Body))
+	}
+
+	// Previews are read like a managed `previews` block, the other values like an import.
+	service := models.Service{Previews: &models.Previews{}}.FromResponse(*response.JSON200)
+
+	path := fmt.Sprintf("services[%s]", service.Name.ValueString())
+
+	result, ok := e.convert(path, service)
+
+	if !ok {
+		return nil
+	}
+
+	label := e.config.Label(result.Name)
+
+	e.config.Service(label, result)
+	e.imports.Import("render_service."+label, serviceId)
+
+	envVars, err := e.listEnvVars(ctx, serviceId)
+
+	if err != nil {
+		return fmt.Errorf("failed to list environment variables: %s", err)
+	}
+
+	if len(envVars) > 0 {
+		environment := tfgen.Environment{}
+
+		for _, envVar := range envVars {
+			name := e.variables.Label(label + "_" + envVar.Key)
+
+			e.variables.Variable(name, fmt.Sprintf("%s of the %s service.", envVar.Key, label), true)
+			e.count++
+
+			environment.Variables = append(environment.Variables, tfgen.Variable{Key: envVar.Key, Expression: "var." + name})
+		}
+
+		e.config.Environment(label, label, environment)
+		e.imports.Import("render_service_environment."+label, serviceId)
+	}
+
+	// Only web services and static sites are reachable through custom domains and headers.
+	if result.Type != string(render.WebService) && result.Type != string(render.StaticSite) {
+		return nil
+	}
+
+	domains, err := e.listCustomDomains(ctx, serviceId)
+
+	if err != nil {
+		return fmt.Errorf("failed to list custom domains: %s", err)
+	}
+
+	for _, domain := range domains {
+		domainLabel := e.config.Label(*domain.Name)
+
+		e.config.CustomDomain(domainLabel, label, *domain.Name)
+		e.imports.Import("render_service_custom_domain."+domainLabel, serviceId+":"+*domain.Name)
+	}
+
+	if result.Type != string(render.StaticSite) {
+		return nil
+	}
+
+	headers, err := e.listHeaders(ctx, serviceId)
+
+	if err != nil {
+		return fmt.Errorf("failed to list headers: %s", err)
+	}
+
+	if len(headers) > 0 {
+		items := make([]tfgen.Header, len(headers))
+
+		for i, header := range headers {
+			items[i] = tfgen.Header{Path: header.Path, Name: header.Name, Value: header.Value}
+		}
+
+		e.config.Headers(label, label, items)
+		e.imports.Import("render_service_headers."+label, serviceId)
+	}
+
+	return nil
+}
+
+// convert maps a service read by the provider onto the arguments of its configuration.
+func (e *exporter) convert(path string, s models.Service) (tfgen.Service, bool) {
+	result := tfgen.Service{
+		Name:          s.Name.ValueString(),
+		Type:          s.Type.ValueString(),
+		Repo:          s.Repo.ValueString(),
+		Branch:        s.Branch.ValueString(),
+		Owner:         s.Owner.ValueString(),
+		RootDirectory: s.RootDirectory.ValueString(),
+	}
+
+	if !s.AutoDeploy.IsNull() {
+		autoDeploy := s.AutoDeploy.ValueBool()
+		result.AutoDeploy = &autoDeploy
+	}
+
+	if s.BuildFilter != nil {
+		result.BuildFilter = &tfgen.BuildFilter{
+			Paths:        values(s.BuildFilter.Paths),
+			IgnoredPaths: values(s.BuildFilter.IgnoredPaths),
+		}
+	}
+
+	if s.Previews != nil && s.Previews.Generation.ValueString() != "off" {
+		result.Previews = &tfgen.Previews{
+			Generation:      s.Previews.Generation.ValueString(),
+			ExpireAfterDays: int(s.Previews.ExpireAfterDays.ValueInt64()),
+			Plan:            s.Previews.Plan.ValueString(),
+		}
+	}
+
+	var native *models.WebServiceDetailsNative
+	var disk *models.Disk
+
+	switch {
+	case s.WebServiceDetails != nil:
+		details := s.WebServiceDetails
+
+		result.Env = details.Env.ValueString()
+		result.Region = details.Region.ValueString()
+		result.Plan = details.Plan.ValueString()
+		result.HealthCheckPath = details.HealthCheckPath.ValueString()
+		result.PreDeployCommand = details.PreDeployCommand.ValueString()
+		result.DockerCommand = details.DockerCommand.ValueString()
+		result.MaxShutdownDelaySeconds = int(details.MaxShutdownDelaySeconds.ValueInt64())
+		native = details.Native
+	case s.PrivateServiceDetails != nil:
+		details := s.PrivateServiceDetails
+
+		result.Env = details.Env.ValueString()
+		result.Region = details.Region.ValueString()
+		result.Plan = details.Plan.ValueString()
+		result.PreDeployCommand = details.PreDeployCommand.ValueString()
+		disk = details.Disk
+	case s.BackgroundWorkerDetails != nil:
+		details := s.BackgroundWorkerDetails
+
+		result.Env = details.Env.ValueString()
+		result.Region = details.Region.ValueString()
+		result.Plan = details.Plan.ValueString()
+		result.PreDeployCommand = details.PreDeployCommand.ValueString()
+		native = details.Native
+		disk = details.Disk
+
+		// Background workers have no previews
+		result.Previews = nil
+	case s.StaticSiteDetails != nil:
+		result.BuildCommand = s.StaticSiteDetails.BuildCommand.ValueString()
+		result.PublishPath = s.StaticSiteDetails.PublishPath.ValueString()
+	default:
+		e.warn("%s: services of type %q are not supported by the provider, skipped", path, s.Type.ValueString())
+		return result, false
+	}
+
+	if native != nil {
+		result.BuildCommand = native.BuildCommand.ValueString()
+		result.StartCommand = native.StartCommand.ValueString()
+	}
+
+	if disk != nil {
+		result.Disk = &tfgen.Disk{Name: disk.Name.ValueString()}
+		e.warn("%s: the Render API doesn't return the mount path and size of disk %q, set `mount_path` and `size_gb` before planning", path, result.Disk.Name)
+	}
+
+	return result, true
+}
+
+func (e *exporter) listServices(ctx context.Context, ownerId string) ([]render.Service, error) {
+	pageLimit := render.LimitParam(utils.PageLimit)
+	params := &render.GetServicesParams{OwnerId: &render.OwnerIdParam{ownerId}, Limit: &pageLimit}
+
+	return utils.Paginate(func(cursor *[]byte) (utils.Page[render.Service, []byte], error) {
+		var page utils.Page[render.Service, []byte]
+
+		params.Cursor = cursor
+
+		response, err := e.clients.Client.GetServicesWithResponse(ctx, params)
+
+		if err != nil {
+			return page, err
+		}
+
+		if response.StatusCode() != http.StatusOK {
+			return page, fmt.Errorf("%s %s", response.Status(), string(response.Body))
+		}
+
+		for _, item := range *response.JSON200 {
+			if item.Service != nil {
+				page.Items = append(page.Items, *item.Service)
+			}
+
+			page.Cursor = item.Cursor
+		}
+
+		page.Size = len(*response.JSON200)
+
+		return page, nil
+	})
+}
+
+func (e *exporter) listEnvVars(ctx context.Context, serviceId string) ([]render.EnvVar, error) {
+	pageLimit := render.LimitParam(utils.PageLimit)
+	params := &render.GetEnvVarsForServiceParams{Limit: &pageLimit}
+
+	return utils.Paginate(func(cursor *[]byte) (utils.Page[render.EnvVar, []byte], error) {
+		var page utils.Page[render.EnvVar, []byte]
+
+		params.Cursor = cursor
+
+		response, err := e.clients.Client.GetEnvVarsForServiceWithResponse(ctx, serviceId, params)
+
+		if err != nil {
+			return page, err
+		}
+
+		if response.StatusCode() != http.StatusOK {
+			return page, fmt.Errorf("%s %s", response.Status(), string(response.Body))
+		}
+
+		for _, item := range *response.JSON200 {
+			if item.EnvVar != nil {
+				page.Items = append(page.Items, *item.EnvVar)
+			}
+
+			page.Cursor = item.Cursor
+		}
+
+		page.Size = len(*response.JSON200)
+
+		return page, nil
+	})
+}
+
+func (e *exporter) listCustomDomains(ctx context.Context, serviceId string) ([]render.CustomDomain, error) {
+	pageLimit := render.LimitParam(utils.PageLimit)
+	params := &render.GetCustomDomainsParams{Limit: &pageLimit}
+
+	return utils.Paginate(func(cursor *[]byte) (utils.Page[render.CustomDomain, []byte], error) {
+		var page utils.Page[render.CustomDomain, []byte]
+
+		params.Cursor = cursor
+
+		response, err := e.clients.Client.GetCustomDomainsWithResponse(ctx, serviceId, params)
+
+		if err != nil {
+			return page, err
+		}
+
+		if response.StatusCode() != http.StatusOK {
+			return page, fmt.Errorf("%s %s", response.Status(), string(response.Body))
+		}
+
+		for _, item := range *response.JSON200 {
+			if item.CustomDomain != nil && item.CustomDomain.Name != nil {
+				page.Items = append(page.Items, *item.CustomDomain)
+			}
+
+			page.Cursor = item.Cursor
+		}
+
+		page.Size = len(*response.JSON200)
+
+		return page, nil
+	})
+}
+
+func (e *exporter) listHeaders(ctx context.Context, serviceId string) ([]render.Header, error) {
+	pageLimit := render.LimitParam(utils.PageLimit)
+	params := &render.GetHeadersParams{Limit: &pageLimit}
+
+	return utils.Paginate(func(cursor *[]byte) (utils.Page[render.Header, []byte], error) {
+		var page utils.Page[render.Header, []byte]
+
+		params.Cursor = cursor
+
+		response, err := e.clients.Client.GetHeadersWithResponse(ctx, serviceId, params)
+
+		if err != nil {
+			return page, err
+		}
+
+		if response.StatusCode() != http.StatusOK {
+			return page, fmt.Errorf("%s %s", response.Status(), string(response.Body))
+		}
+
+		for _, item := range *response.JSON200 {
+			if item.Headers != nil {
+				page.Items = append(page.Items, *item.Headers)
+			}
+
+			page.Cursor = item.Cursor
+		}
+
+		page.Size = len(*response.JSON200)
+
+		return page, nil
+	})
+}
+
+func values(items []types.String) []string {
+	result := make([]string, len(items))
+
+	for i, item := range items {
+		result[i] = item.ValueString()
+	}
+
+	return result
+}
+
+func (e *exporter) warn(format string, args ...interface{}) {
+	e.warnings = append(e.warnings, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,116 @@
+// Command render-export generates Terraform configuration for the services of a
+// Render owner created outside of Terraform, along with Terraform 1.5 `import`
+// blocks to adopt them.
+//
+//	RENDER_API_KEY=... render-export -owner <owner_id> [-o <directory>]
+//
+// It writes `services.tf`, with the services, their environment variables,
+// custom domains and headers, and `imports.tf`, which can be removed once the
+// resources are imported. Everything the provider can't express is reported as
+// a warning on stderr.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/deepmap/oapi-codegen/pkg/securityprovider"
+	"github.com/jackall3n/render-go"
+	"github.com/jackall3n/terraform-provider-render/render/api"
+)
+
+const defaultAPIURL = "https://api.render.com/v1"
+
+func main() {
+	if err := run(context.Background(), os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		fmt.Fprintf(os.Stderr, "render-export: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) error {
+	flags := flag.NewFlagSet("render-export", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+
+	var ownerId, apiURL, output string
+
+	flags.StringVar(&ownerId, "owner", os.Getenv("RENDER_OWNER_ID"), "the ID of the owner whose services are exported, defaults to `RENDER_OWNER_ID`")
+	flags.StringVar(&apiURL, "api-url", os.Getenv("RENDER_API_URL"), "the base URL of the Render API, defaults to `RENDER_API_URL` or "+defaultAPIURL)
+	flags.StringVar(&output, "o", ".", "the directory the configuration is written to")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if flags.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %v", flags.Args())
+	}
+
+	if ownerId == "" {
+		return fmt.Errorf("no owner, pass -owner or set RENDER_OWNER_ID")
+	}
+
+	apiKey := os.Getenv("RENDER_API_KEY")
+
+	if apiKey == "" {
+		return fmt.Errorf("no api key, set RENDER_API_KEY")
+	}
+
+	if apiURL == "" {
+		apiURL = defaultAPIURL
+	}
+
+	bearer, err := securityprovider.NewSecurityProviderBearerToken(apiKey)
+
+	if err != nil {
+		return err
+	}
+
+	client, err := render.NewClientWithResponses(apiURL, render.WithRequestEditorFn(bearer.Intercept))
+
+	if err != nil {
+		return err
+	}
+
+	files, warnings, err := Export(ctx, Clients{Client: client, API: api.NewClient(apiURL, bearer.Intercept)}, ownerId)
+
+	for _, warning := range warnings {
+		fmt.Fprintf(stderr, "warning: %s\n", warning)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(files))
+
+	for name := range files {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	// Existing configuration is never overwritten.
+	for _, name := range names {
+		if _, err := os.Stat(filepath.Join(output, name)); err == nil {
+			return fmt.Errorf("%s already exists", filepath.Join(output, name))
+		}
+	}
+
+	for _, name := range names {
+		path := filepath.Join(output, name)
+
+		if err := os.WriteFile(path, files[name], 0o644); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(stdout, "wrote %s\n", path)
+	}
+
+	return nil
+}
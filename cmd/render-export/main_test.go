@@ -0,0 +1,228 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/jackall3n/render-go"
+	"github.com/jackall3n/terraform-provider-render/internal/fakerender"
+	provider "github.com/jackall3n/terraform-provider-render/render"
+	"github.com/jackall3n/terraform-provider-render/render/api"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+const ownerId = "usr-00000001"
+
+// newTestServer returns a fake Render API with services of every type, and one of another owner.
+func newTestServer(t *testing.T) *fakerender.Server {
+	t.Helper()
+
+	server := fakerender.New()
+	t.Cleanup(server.Close)
+
+	name := "Jane"
+	email := "jane@example.com"
+	server.AddOwner(render.Owner{Id: ownerId, Name: &name, Email: &email})
+
+	web := addService(t, server, `{
+		"name": "API", "type": "web_service", "ownerId": "usr-00000001", "repo": "https://github.com/acme/app",
+		"autoDeploy": "no", "rootDir": "api", "buildFilter": {"paths": ["api/**"], "ignoredPaths": ["api/docs/**"]},
+		"serviceDetails": {
+			"env": "node", "region": "frankfurt", "plan": "standard", "healthCheckPath": "/health",
+			"envSpecificDetails": {"buildCommand": "npm ci", "startCommand": "npm start", "preDeployCommand": "npm run migrate"}
+		}
+	}`)
+
+	server.SetEnvVars(web, []render.EnvVar{{Key: "NODE_ENV", Value: "production"}, {Key: "API_TOKEN", Value: "secret"}})
+	server.AddCustomDomain(web, "api.example.com")
+
+	site := addService(t, server, `{
+		"name": "docs", "type": "static_site", "ownerId": "usr-00000001", "repo": "https://github.com/acme/docs",
+		"serviceDetails": {"buildCommand": "make html", "publishPath": "build/html"}
+	}`)
+
+	server.AddCustomDomain(site, "docs.example.com")
+	server.SetHeaders(site, []render.Header{{Path: "/*", Name: "X-Frame-Options", Value: "DENY"}})
+
+	addService(t, server, `{
+		"name": "jobs", "type": "background_worker", "ownerId": "usr-00000001", "repo": "https://github.com/acme/app",
+		"serviceDetails": {"env": "docker", "plan": "starter"}
+	}`)
+
+	addService(t, server, `{
+		"name": "nightly", "type": "cron_job", "ownerId": "usr-00000001", "repo": "https://github.com/acme/app",
+		"serviceDetails": {"env": "python", "schedule": "0 3 * * *"}
+	}`)
+
+	addService(t, server, `{"name": "other", "type": "web_service", "ownerId": "tea-00000001", "repo": "https://github.com/other/app", "serviceDetails": {"env": "go"}}`)
+
+	return server
+}
+
+func addService(t *testing.T, server *fakerender.Server, service string) string {
+	t.Helper()
+
+	var post api.ServicePOST
+
+	if err := json.Unmarshal([]byte(service), &post); err != nil {
+		t.Fatal(err)
+	}
+
+	id, err := server.AddService(post)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return id
+}
+
+func TestRun(t *testing.T) {
+	server := newTestServer(t)
+	output := t.TempDir()
+
+	t.Setenv("RENDER_API_KEY", "rnd_test")
+
+	var stdout, stderr bytes.Buffer
+
+	if err := run(context.Background(), []string{"-api-url", server.URL, "-owner", ownerId, "-o", output}, &stdout, &stderr); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"services.tf", "imports.tf", "variables.tf"} {
+		actual, err := os.ReadFile(filepath.Join(output, name))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Values of environment variables may be secrets, and the output is meant to be committed
+		if strings.Contains(string(actual), `"secret"`) {
+			t.Errorf("expected no environment variable values in %s:\n%s", name, actual)
+		}
+
+		golden := filepath.Join("testdata", name)
+
+		if *update {
+			if err := os.WriteFile(golden, actual, 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		expected, err := os.ReadFile(golden)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(actual) != string(expected) {
+			t.Errorf("unexpected %s, run with -update to accept it:\n%s", name, actual)
+		}
+	}
+
+	warnings := []string{
+		`warning: services[nightly]: services of type "cron_job" are not supported by the provider, skipped`,
+		"warning: the values of 2 environment variables are not exported, set the input variables of variables.tf before planning, e.g. with TF_VAR_<name>",
+	}
+
+	if actual := strings.Split(strings.TrimSpace(stderr.String()), "\n"); !reflect.DeepEqual(actual, warnings) {
+		t.Errorf("expected warnings\n%s\ngot\n%s", strings.Join(warnings, "\n"), strings.Join(actual, "\n"))
+	}
+
+	// Existing configuration is never overwritten
+	err := run(context.Background(), []string{"-api-url", server.URL, "-owner", ownerId, "-o", output}, &stdout, &stderr)
+
+	if expected := fmt.Sprintf("%s already exists", filepath.Join(output, "imports.tf")); err == nil || err.Error() != expected {
+		t.Errorf("expected %q, got %v", expected, err)
+	}
+}
+
+func TestRunWithoutOwner(t *testing.T) {
+	t.Setenv("RENDER_OWNER_ID", "")
+	t.Setenv("RENDER_API_KEY", "rnd_test")
+
+	var stdout, stderr bytes.Buffer
+
+	err := run(context.Background(), []string{"-o", t.TempDir()}, &stdout, &stderr)
+
+	if err == nil || err.Error() != "no owner, pass -owner or set RENDER_OWNER_ID" {
+		t.Errorf("expected a missing owner error, got %v", err)
+	}
+}
+
+// expectUnchangedValues fails when a planned change alters a value. Imported resources have no
+// sensitivity recorded in state, so Terraform plans an update of the environment variables that
+// only stores it, which is allowed.
+type expectUnchangedValues struct{}
+
+func (expectUnchangedValues) CheckPlan(_ context.Context, req plancheck.CheckPlanRequest, resp *plancheck.CheckPlanResponse) {
+	for _, change := range req.Plan.ResourceChanges {
+		if !reflect.DeepEqual(change.Change.Before, change.Change.After) {
+			resp.Error = fmt.Errorf("expected %s to be imported unchanged, got %v", change.Address, change.Change.Actions)
+			return
+		}
+	}
+}
+
+// TestAccExportedConfig applies the configuration and import blocks of testdata, with the values
+// of the environment variables as input variables. Nothing but the imports may be planned, and
+// the plan after them has to be empty.
+func TestAccExportedConfig(t *testing.T) {
+	server := newTestServer(t)
+
+	var generated string
+
+	for _, name := range []string{"services.tf", "imports.tf", "variables.tf"} {
+		content, err := os.ReadFile(filepath.Join("testdata", name))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		generated += string(content)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"render": providerserver.NewProtocol6WithError(provider.New()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "render" {
+  api_key  = "rnd_test"
+  api_url  = %q
+  owner_id = %q
+}
+`, server.URL, ownerId) + generated,
+				ConfigVariables: config.Variables{
+					"api_node_env":  config.StringVariable("production"),
+					"api_api_token": config.StringVariable("secret"),
+				},
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{expectUnchangedValues{}},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("render_service.api", "web_service_details.health_check_path", "/health"),
+					resource.TestCheckResourceAttr("render_service_environment.api", "variables.API_TOKEN.value", "secret"),
+					resource.TestCheckResourceAttr("render_service_custom_domain.docs_example_com", "domain_name", "docs.example.com"),
+					resource.TestCheckResourceAttr("render_service_headers.docs", "headers.#", "1"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,34 @@
+import {
+  to = render_service.api
+  id = "srv-00000001"
+}
+
+import {
+  to = render_service_environment.api
+  id = "srv-00000001"
+}
+
+import {
+  to = render_service_custom_domain.api_example_com
+  id = "srv-00000001:api.example.com"
+}
+
+import {
+  to = render_service.docs
+  id = "srv-00000002"
+}
+
+import {
+  to = render_service_custom_domain.docs_example_com
+  id = "srv-00000002:docs.example.com"
+}
+
+import {
+  to = render_service_headers.docs
+  id = "srv-00000002"
+}
+
+import {
+  to = render_service.jobs
+  id = "srv-00000003"
+}
@@ -0,0 +1,83 @@
+resource "render_service" "api" {
+  name           = "API"
+  type           = "web_service"
+  repo           = "https://github.com/acme/app"
+  branch         = "main"
+  owner          = "usr-00000001"
+  auto_deploy    = false
+  root_directory = "api"
+  web_service_details = {
+    env                        = "node"
+    region                     = "frankfurt"
+    plan                       = "standard"
+    health_check_path          = "/health"
+    pre_deploy_command         = "npm run migrate"
+    max_shutdown_delay_seconds = 30
+    native = {
+      build_command = "npm ci"
+      start_command = "npm start"
+    }
+  }
+  build_filter {
+    paths         = ["api/**"]
+    ignored_paths = ["api/docs/**"]
+  }
+}
+
+resource "render_service_environment" "api" {
+  service = render_service.api.id
+  variables = {
+    NODE_ENV = {
+      value = var.api_node_env
+    }
+    API_TOKEN = {
+      value = var.api_api_token
+    }
+  }
+}
+
+resource "render_service_custom_domain" "api_example_com" {
+  service_id  = render_service.api.id
+  domain_name = "api.example.com"
+}
+
+resource "render_service" "docs" {
+  name        = "docs"
+  type        = "static_site"
+  repo        = "https://github.com/acme/docs"
+  branch      = "main"
+  owner       = "usr-00000001"
+  auto_deploy = true
+  static_site_details = {
+    build_command = "make html"
+    publish_path  = "build/html"
+  }
+}
+
+resource "render_service_custom_domain" "docs_example_com" {
+  service_id  = render_service.docs.id
+  domain_name = "docs.example.com"
+}
+
+resource "render_service_headers" "docs" {
+  service_id = render_service.docs.id
+  headers = [{
+    path  = "/*"
+    name  = "X-Frame-Options"
+    value = "DENY"
+  }]
+}
+
+resource "render_service" "jobs" {
+  name        = "jobs"
+  type        = "background_worker"
+  repo        = "https://github.com/acme/app"
+  branch      = "main"
+  owner       = "usr-00000001"
+  auto_deploy = true
+  background_worker_details = {
+    env    = "docker"
+    region = "oregon"
+    plan   = "starter"
+  }
+}
@@ -0,0 +1,11 @@
+variable "api_node_env" {
+  type        = string
+  description = "NODE_ENV of the api service."
+  sensitive   = true
+}
+
+variable "api_api_token" {
+  type        = string
+  description = "API_TOKEN of the api service."
+  sensitive   = true
+}
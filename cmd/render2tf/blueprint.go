@@ -0,0 +1,158 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Blueprint is the part of the `render.yaml` specification render2tf understands.
+// Keys it doesn't know are collected while decoding, so they can be reported.
+type Blueprint struct {
+	Services     []Service     `yaml:"services"`
+	Databases    []Database    `yaml:"databases"`
+	EnvVarGroups []EnvVarGroup `yaml:"envVarGroups"`
+
+	unsupported []string
+}
+
+type Service struct {
+	Type              string       `yaml:"type"`
+	Name              string       `yaml:"name"`
+	Runtime           string       `yaml:"runtime"`
+	Env               string       `yaml:"env"`
+	Repo              string       `yaml:"repo"`
+	Branch            string       `yaml:"branch"`
+	RootDir           string       `yaml:"rootDir"`
+	AutoDeploy        *bool        `yaml:"autoDeploy"`
+	AutoDeployTrigger string       `yaml:"autoDeployTrigger"`
+	Region            string       `yaml:"region"`
+	Plan              string       `yaml:"plan"`
+	BuildCommand      string       `yaml:"buildCommand"`
+	StartCommand      string       `yaml:"startCommand"`
+	PreDeployCommand  string       `yaml:"preDeployCommand"`
+	DockerCommand     string       `yaml:"dockerCommand"`
+	HealthCheckPath   string       `yaml:"healthCheckPath"`
+	StaticPublishPath string       `yaml:"staticPublishPath"`
+	BuildFilter       *BuildFilter `yaml:"buildFilter"`
+	Disk              *Disk        `yaml:"disk"`
+	Domains           []string     `yaml:"domains"`
+	Headers           []Header     `yaml:"headers"`
+	EnvVars           []EnvVar     `yaml:"envVars"`
+
+	MaxShutdownDelaySeconds    int       `yaml:"maxShutdownDelaySeconds"`
+	PullRequestPreviewsEnabled *bool     `yaml:"pullRequestPreviewsEnabled"`
+	Previews                   *Previews `yaml:"previews"`
+
+	unsupported []string
+}
+
+type BuildFilter struct {
+	Paths        []string `yaml:"paths"`
+	IgnoredPaths []string `yaml:"ignoredPaths"`
+}
+
+type Disk struct {
+	Name      string `yaml:"name"`
+	MountPath string `yaml:"mountPath"`
+	SizeGB    int    `yaml:"sizeGB"`
+}
+
+type Header struct {
+	Path  string `yaml:"path"`
+	Name  string `yaml:"name"`
+	Value string `yaml:"value"`
+}
+
+type Previews struct {
+	Generation      string `yaml:"generation"`
+	ExpireAfterDays int    `yaml:"expireAfterDays"`
+	Plan            string `yaml:"plan"`
+}
+
+type EnvVar struct {
+	Key           string        `yaml:"key"`
+	Value         *string       `yaml:"value"`
+	GenerateValue bool          `yaml:"generateValue"`
+	Sync          *bool         `yaml:"sync"`
+	FromDatabase  *FromDatabase `yaml:"fromDatabase"`
+	FromService   *FromService  `yaml:"fromService"`
+	FromGroup     string        `yaml:"fromGroup"`
+
+	unsupported []string
+}
+
+type FromDatabase struct {
+	Name     string `yaml:"name"`
+	Property string `yaml:"property"`
+}
+
+type FromService struct {
+	Name      string `yaml:"name"`
+	Type      string `yaml:"type"`
+	Property  string `yaml:"property"`
+	EnvVarKey string `yaml:"envVarKey"`
+}
+
+type Database struct {
+	Name string `yaml:"name"`
+}
+
+type EnvVarGroup struct {
+	Name    string   `yaml:"name"`
+	EnvVars []EnvVar `yaml:"envVars"`
+}
+
+func (b *Blueprint) UnmarshalYAML(value *yaml.Node) error {
+	type plain Blueprint
+
+	b.unsupported = unknownKeys(value, reflect.TypeOf(plain{}), "version")
+
+	return value.Decode((*plain)(b))
+}
+
+func (s *Service) UnmarshalYAML(value *yaml.Node) error {
+	type plain Service
+
+	s.unsupported = unknownKeys(value, reflect.TypeOf(plain{}))
+
+	return value.Decode((*plain)(s))
+}
+
+func (e *EnvVar) UnmarshalYAML(value *yaml.Node) error {
+	type plain EnvVar
+
+	e.unsupported = unknownKeys(value, reflect.TypeOf(plain{}))
+
+	return value.Decode((*plain)(e))
+}
+
+// unknownKeys returns the keys of a mapping that are neither a `yaml` field of t nor ignored.
+func unknownKeys(value *yaml.Node, t reflect.Type, ignored ...string) []string {
+	if value.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	known := map[string]bool{}
+
+	for _, key := range ignored {
+		known[key] = true
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		if tag := t.Field(i).Tag.Get("yaml"); tag != "" {
+			known[strings.Split(tag, ",")[0]] = true
+		}
+	}
+
+	var keys []string
+
+	for i := 0; i < len(value.Content); i += 2 {
+		if key := value.Content[i].Value; !known[key] {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
@@ -0,0 +1,395 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/jackall3n/terraform-provider-render/internal/tfgen"
+)
+
+// Options are the values render.yaml leaves to the repository it is in.
+type Options struct {
+	// Repo is used for services without `repo`, i.e. those built from the repository of render.yaml.
+	Repo string
+}
+
+// serviceTypes maps the `type` of render.yaml services to render_service types.
+var serviceTypes = map[string]string{
+	"web":    "web_service",
+	"pserv":  "private_service",
+	"worker": "background_worker",
+}
+
+// runtimes are the `runtime` values render_service supports as `env`.
+var runtimes = map[string]bool{
+	"docker": true,
+	"elixir": true,
+	"go":     true,
+	"node":   true,
+	"python": true,
+	"ruby":   true,
+	"rust":   true,
+}
+
+type converter struct {
+	options   Options
+	file      *tfgen.File
+	warnings  []string
+	groups    map[string]EnvVarGroup
+	databases map[string]bool
+
+	variables     []variable
+	variableNames map[string]bool
+}
+
+type variable struct {
+	name        string
+	description string
+}
+
+// service is a converted service, written once every variable is known.
+type service struct {
+	label       string
+	service     tfgen.Service
+	environment *tfgen.Environment
+	domains     []string
+	headers     []tfgen.Header
+}
+
+// Convert returns the Terraform configuration of a blueprint, and warnings about everything
+// that couldn't be converted.
+func Convert(blueprint Blueprint, options Options) ([]byte, []string, error) {
+	c := &converter{
+		options:       options,
+		file:          tfgen.NewFile(),
+		groups:        map[string]EnvVarGroup{},
+		databases:     map[string]bool{},
+		variableNames: map[string]bool{},
+	}
+
+	for _, key := range blueprint.unsupported {
+		c.warn("%s is not supported, skipped", key)
+	}
+
+	for _, database := range blueprint.Databases {
+		c.databases[database.Name] = true
+		c.warn("databases[%s]: databases are not supported by the provider, skipped. Variables from it are read from input variables", database.Name)
+	}
+
+	for _, group := range blueprint.EnvVarGroups {
+		c.groups[group.Name] = group
+		c.warn("envVarGroups[%s]: environment groups are not supported by the provider, its variables are copied to the services using it", group.Name)
+	}
+
+	var services []service
+
+	for _, s := range blueprint.Services {
+		converted, err := c.service(s)
+
+		if err != nil {
+			return nil, c.warnings, err
+		}
+
+		if converted != nil {
+			services = append(services, *converted)
+		}
+	}
+
+	// A variable may have been overridden by a later definition of its key.
+	used := map[string]bool{}
+
+	for _, s := range services {
+		if s.environment != nil {
+			for _, expression := range s.environment.Sensitive {
+				used[expression] = true
+			}
+		}
+	}
+
+	for _, v := range c.variables {
+		if used["var."+v.name] {
+			c.file.Variable(v.name, v.description, true)
+		}
+	}
+
+	for _, s := range services {
+		c.file.Service(s.label, s.service)
+
+		if s.environment != nil {
+			c.file.Environment(s.label, s.label, *s.environment)
+		}
+
+		for _, domain := range s.domains {
+			c.file.CustomDomain(c.file.Label(s.label+"_"+domain), s.label, domain)
+		}
+
+		if len(s.headers) > 0 {
+			c.file.Headers(s.label, s.label, s.headers)
+		}
+	}
+
+	return c.file.Bytes(), c.warnings, nil
+}
+
+func (c *converter) service(s Service) (*service, error) {
+	path := fmt.Sprintf("services[%s]", s.Name)
+
+	runtime := s.Runtime
+
+	if runtime == "" {
+		runtime = s.Env
+	}
+
+	serviceType, ok := serviceTypes[s.Type]
+
+	if ok && s.Type == "web" && runtime == "static" {
+		serviceType = "static_site"
+	} else if !ok || !runtimes[runtime] {
+		c.warn("%s: services of type %q with runtime %q are not supported by the provider, skipped", path, s.Type, runtime)
+		return nil, nil
+	}
+
+	for _, key := range s.unsupported {
+		c.warn("%s.%s is not supported, skipped", path, key)
+	}
+
+	repo := s.Repo
+
+	if repo == "" {
+		repo = c.options.Repo
+	}
+
+	if repo == "" {
+		return nil, fmt.Errorf("%s: no repo, pass -repo with the repository of render.yaml", path)
+	}
+
+	result := &service{
+		label: c.file.Label(s.Name),
+		service: tfgen.Service{
+			Name:             s.Name,
+			Type:             serviceType,
+			Repo:             repo,
+			Branch:           s.Branch,
+			AutoDeploy:       s.AutoDeploy,
+			RootDirectory:    s.RootDir,
+			Region:           s.Region,
+			Plan:             s.Plan,
+			BuildCommand:     s.BuildCommand,
+			StartCommand:     s.StartCommand,
+			PreDeployCommand: s.PreDeployCommand,
+		},
+		domains: s.Domains,
+	}
+
+	switch s.AutoDeployTrigger {
+	case "":
+	case "off":
+		result.service.AutoDeploy = boolPtr(false)
+	case "commit":
+		result.service.AutoDeploy = boolPtr(true)
+	default:
+		c.warn("%s.autoDeployTrigger %q is not supported, skipped", path, s.AutoDeployTrigger)
+	}
+
+	if s.BuildFilter != nil {
+		result.service.BuildFilter = &tfgen.BuildFilter{Paths: s.BuildFilter.Paths, IgnoredPaths: s.BuildFilter.IgnoredPaths}
+	}
+
+	if serviceType == "background_worker" && (s.Previews != nil || s.PullRequestPreviewsEnabled != nil) {
+		c.warn("%s: previews are not supported for background workers, skipped", path)
+	} else if s.Previews != nil {
+		result.service.Previews = &tfgen.Previews{
+			Generation:      s.Previews.Generation,
+			ExpireAfterDays: s.Previews.ExpireAfterDays,
+			Plan:            s.Previews.Plan,
+		}
+	} else if s.PullRequestPreviewsEnabled != nil {
+		result.service.Previews = &tfgen.Previews{Generation: "off"}
+
+		if *s.PullRequestPreviewsEnabled {
+			result.service.Previews.Generation = "automatic"
+		}
+	}
+
+	if serviceType == "static_site" {
+		result.service.PublishPath = s.StaticPublishPath
+
+		for _, header := range s.Headers {
+			result.headers = append(result.headers, tfgen.Header(header))
+		}
+
+		for _, field := range []struct {
+			name  string
+			value string
+		}{{"startCommand", s.StartCommand}, {"preDeployCommand", s.PreDeployCommand}, {"region", s.Region}, {"plan", s.Plan}} {
+			if field.value != "" {
+				c.warn("%s.%s is not supported for static sites, skipped", path, field.name)
+			}
+		}
+	} else {
+		result.service.Env = runtime
+
+		if len(s.Headers) > 0 {
+			c.warn("%s.headers is only supported for static sites, skipped", path)
+		}
+	}
+
+	if serviceType == "private_service" && (s.BuildCommand != "" || s.StartCommand != "") {
+		c.warn("%s: buildCommand and startCommand are not supported for private services by the provider, skipped", path)
+	}
+
+	if serviceType == "web_service" {
+		result.service.HealthCheckPath = s.HealthCheckPath
+		result.service.DockerCommand = s.DockerCommand
+		result.service.MaxShutdownDelaySeconds = s.MaxShutdownDelaySeconds
+	} else {
+		for _, field := range []struct {
+			name string
+			set  bool
+		}{{"healthCheckPath", s.HealthCheckPath != ""}, {"dockerCommand", s.DockerCommand != ""}, {"maxShutdownDelaySeconds", s.MaxShutdownDelaySeconds != 0}} {
+			if field.set {
+				c.warn("%s.%s is only supported for web services, skipped", path, field.name)
+			}
+		}
+	}
+
+	if s.Disk != nil {
+		if serviceType == "private_service" || serviceType == "background_worker" {
+			result.service.Disk = &tfgen.Disk{Name: s.Disk.Name, MountPath: s.Disk.MountPath, SizeGB: s.Disk.SizeGB}
+		} else {
+			c.warn("%s.disk is only supported for private services and background workers, skipped", path)
+		}
+	}
+
+	result.environment = c.environment(path, result.label, s.EnvVars)
+
+	return result, nil
+}
+
+// environment converts the envVars of a service. Secrets, i.e. `sync: false` and values from
+// databases, become sensitive input variables.
+func (c *converter) environment(path string, label string, envVars []EnvVar) *tfgen.Environment {
+	if len(envVars) == 0 {
+		return nil
+	}
+
+	var keys []string
+
+	values := map[string]tfgen.Variable{}
+	sensitive := map[string]string{}
+
+	// Later definitions of a key win, as they do on Render.
+	set := func(v tfgen.Variable) {
+		if _, ok := sensitive[v.Key]; !ok {
+			if _, ok := values[v.Key]; !ok {
+				keys = append(keys, v.Key)
+			}
+		}
+
+		delete(sensitive, v.Key)
+		values[v.Key] = v
+	}
+
+	setSensitive := func(key string, name string, description string) {
+		if _, ok := values[key]; !ok {
+			if _, ok := sensitive[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+
+		delete(values, key)
+		sensitive[key] = "var." + c.variable(name, description)
+	}
+
+	var expand func(envVars []EnvVar, path string)
+
+	expand = func(envVars []EnvVar, path string) {
+		for _, envVar := range envVars {
+			envPath := fmt.Sprintf("%s.envVars[%s]", path, envVar.Key)
+
+			for _, key := range envVar.unsupported {
+				c.warn("%s.%s is not supported, skipped", envPath, key)
+			}
+
+			switch {
+			case envVar.FromGroup != "":
+				group, ok := c.groups[envVar.FromGroup]
+
+				if !ok {
+					c.warn("%s.envVars: environment group %q is not defined, skipped", path, envVar.FromGroup)
+					continue
+				}
+
+				expand(group.EnvVars, fmt.Sprintf("envVarGroups[%s]", group.Name))
+			case envVar.Key == "":
+				c.warn("%s.envVars: variables without a key are not supported, skipped", path)
+			case envVar.FromDatabase != nil:
+				database := envVar.FromDatabase
+
+				if !c.databases[database.Name] {
+					c.warn("%s.fromDatabase: database %q is not defined", envPath, database.Name)
+				}
+
+				setSensitive(envVar.Key, database.Name+"_"+snake(database.Property), fmt.Sprintf("The %s of the %s database.", database.Property, database.Name))
+			case envVar.FromService != nil:
+				c.warn("%s.fromService is not supported, skipped. Set the variable in `variables` or `sensitive_variables`", envPath)
+			case envVar.GenerateValue:
+				set(tfgen.Variable{Key: envVar.Key, Generated: true})
+			case envVar.Sync != nil && !*envVar.Sync:
+				setSensitive(envVar.Key, label+"_"+snake(envVar.Key), fmt.Sprintf("%s of the %s service.", envVar.Key, label))
+			case envVar.Value != nil:
+				set(tfgen.Variable{Key: envVar.Key, Value: *envVar.Value})
+			default:
+				c.warn("%s has no value, skipped", envPath)
+			}
+		}
+	}
+
+	expand(envVars, path)
+
+	if len(keys) == 0 {
+		return nil
+	}
+
+	environment := &tfgen.Environment{Variables: []tfgen.Variable{}}
+
+	for _, key := range keys {
+		if v, ok := values[key]; ok {
+			environment.Variables = append(environment.Variables, v)
+		}
+	}
+
+	if len(sensitive) > 0 {
+		environment.Sensitive = sensitive
+	}
+
+	return environment
+}
+
+// variable declares a sensitive input variable once and returns its name.
+func (c *converter) variable(name string, description string) string {
+	name = tfgen.Label(name)
+
+	if !c.variableNames[name] {
+		c.variableNames[name] = true
+		c.variables = append(c.variables, variable{name: name, description: description})
+	}
+
+	return name
+}
+
+func (c *converter) warn(format string, args ...interface{}) {
+	c.warnings = append(c.warnings, fmt.Sprintf(format, args...))
+}
+
+var camelCaseBoundary = regexp.MustCompile(`([a-z0-9])([A-Z])`)
+
+// snake converts a property like `connectionString` to `connection_string`.
+func snake(name string) string {
+	return strings.ToLower(camelCaseBoundary.ReplaceAllString(name, "${1}_${2}"))
+}
+
+func boolPtr(value bool) *bool {
+	return &value
+}
@@ -0,0 +1,78 @@
+// Command render2tf converts a `render.yaml` blueprint to Terraform configuration
+// for this provider. It runs offline, nothing is read from or sent to Render.
+//
+//	render2tf [-repo <url>] [-o main.tf] [render.yaml]
+//
+// Everything the provider can't express, e.g. databases, is reported as a warning
+// on stderr.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		fmt.Fprintf(os.Stderr, "render2tf: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdout io.Writer, stderr io.Writer) error {
+	flags := flag.NewFlagSet("render2tf", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+
+	var options Options
+	var output string
+
+	flags.StringVar(&options.Repo, "repo", "", "the repository of render.yaml, used for services without `repo`")
+	flags.StringVar(&output, "o", "", "write the configuration to this file instead of stdout")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	input := "render.yaml"
+
+	switch flags.NArg() {
+	case 0:
+	case 1:
+		input = flags.Arg(0)
+	default:
+		return fmt.Errorf("expected a single render.yaml, got %d arguments", flags.NArg())
+	}
+
+	source, err := os.ReadFile(input)
+
+	if err != nil {
+		return err
+	}
+
+	var blueprint Blueprint
+
+	if err := yaml.Unmarshal(source, &blueprint); err != nil {
+		return fmt.Errorf("failed to parse %s: %s", input, err)
+	}
+
+	config, warnings, err := Convert(blueprint, options)
+
+	for _, warning := range warnings {
+		fmt.Fprintf(stderr, "warning: %s\n", warning)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if output == "" {
+		_, err = stdout.Write(config)
+		return err
+	}
+
+	return os.WriteFile(output, config, 0o644)
+}
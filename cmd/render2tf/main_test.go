@@ -0,0 +1,156 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	renderapi "github.com/jackall3n/render-go"
+	"github.com/jackall3n/terraform-provider-render/internal/fakerender"
+	"github.com/jackall3n/terraform-provider-render/render"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+func TestRun(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	if err := run([]string{"-repo", "https://github.com/acme/app", "testdata/render.yaml"}, &stdout, &stderr); err != nil {
+		t.Fatal(err)
+	}
+
+	golden := filepath.Join("testdata", "main.tf")
+
+	if *update {
+		if err := os.WriteFile(golden, stdout.Bytes(), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expected, err := os.ReadFile(golden)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if stdout.String() != string(expected) {
+		t.Errorf("unexpected configuration, run with -update to accept it:\n%s", stdout.String())
+	}
+
+	warnings := []string{
+		"warning: databases[db]: databases are not supported by the provider, skipped. Variables from it are read from input variables",
+		"warning: envVarGroups[shared]: environment groups are not supported by the provider, its variables are copied to the services using it",
+		"warning: services[API].numInstances is not supported, skipped",
+		"warning: services[API].envVars[WORKER_HOST].fromService is not supported, skipped. Set the variable in `variables` or `sensitive_variables`",
+		"warning: services[site].routes is not supported, skipped",
+		`warning: services[nightly]: services of type "cron" with runtime "python" are not supported by the provider, skipped`,
+	}
+
+	if actual := strings.Split(strings.TrimSpace(stderr.String()), "\n"); !reflect.DeepEqual(actual, warnings) {
+		t.Errorf("expected warnings\n%s\ngot\n%s", strings.Join(warnings, "\n"), strings.Join(actual, "\n"))
+	}
+}
+
+func TestRunWithoutRepo(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	err := run([]string{"testdata/render.yaml"}, &stdout, &stderr)
+
+	if err == nil || err.Error() != "services[API]: no repo, pass -repo with the repository of render.yaml" {
+		t.Errorf("expected a missing repo error, got %v", err)
+	}
+
+	if stdout.Len() != 0 {
+		t.Errorf("expected no configuration, got:\n%s", stdout.String())
+	}
+}
+
+func TestConvertEnvironmentOverrides(t *testing.T) {
+	value := func(v string) *string { return &v }
+	sync := false
+
+	blueprint := Blueprint{
+		Services: []Service{{
+			Type:    "pserv",
+			Name:    "api",
+			Runtime: "go",
+			Repo:    "https://github.com/acme/api",
+			EnvVars: []EnvVar{
+				{Key: "TOKEN", Value: value("dev")},
+				{Key: "TOKEN", Sync: &sync},
+				{Key: "MODE", Sync: &sync},
+				{Key: "MODE", Value: value("release")},
+			},
+		}},
+	}
+
+	output, warnings, err := Convert(blueprint, Options{})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(warnings) != 0 {
+		t.Errorf("expected no warnings, got %v", warnings)
+	}
+
+	for _, expected := range []string{`variable "api_token"`, `TOKEN = var.api_token`, `value = "release"`} {
+		if !strings.Contains(string(output), expected) {
+			t.Errorf("expected %q in:\n%s", expected, output)
+		}
+	}
+
+	for _, unexpected := range []string{`value = "dev"`, `variable "api_mode"`} {
+		if strings.Contains(string(output), unexpected) {
+			t.Errorf("expected no %q in:\n%s", unexpected, output)
+		}
+	}
+}
+
+// TestAccConvertedConfig plans the configuration of testdata/main.tf, so it stays valid for the provider schemas.
+func TestAccConvertedConfig(t *testing.T) {
+	server := fakerender.New()
+	t.Cleanup(server.Close)
+
+	name := "Jane"
+	email := "jane@example.com"
+	server.AddOwner(renderapi.Owner{Id: "usr-00000001", Name: &name, Email: &email})
+
+	generated, err := os.ReadFile(filepath.Join("testdata", "main.tf"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"render": providerserver.NewProtocol6WithError(render.New()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "render" {
+  api_key = "rnd_test"
+  api_url = %q
+  email   = %q
+}
+`, server.URL, email) + string(generated),
+				ConfigVariables: config.Variables{
+					"api_stripe_key":       config.StringVariable("sk_test"),
+					"db_connection_string": config.StringVariable("postgres://db"),
+				},
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
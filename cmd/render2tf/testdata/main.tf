@@ -0,0 +1,118 @@
+variable "api_stripe_key" {
+  type        = string
+  description = "STRIPE_KEY of the api service."
+  sensitive   = true
+}
+
+variable "db_connection_string" {
+  type        = string
+  description = "The connectionString of the db database."
+  sensitive   = true
+}
+
+resource "render_service" "api" {
+  name           = "API"
+  type           = "web_service"
+  repo           = "https://github.com/acme/app"
+  branch         = "main"
+  root_directory = "services/api"
+  web_service_details = {
+    env                        = "node"
+    region                     = "frankfurt"
+    plan                       = "standard"
+    health_check_path          = "/healthz"
+    pre_deploy_command         = "npm run migrate"
+    max_shutdown_delay_seconds = 60
+    native = {
+      build_command = "npm ci"
+      start_command = "node server.js"
+    }
+  }
+  build_filter {
+    paths         = ["services/api/**"]
+    ignored_paths = ["**/*.md"]
+  }
+  previews {
+    generation        = "manual"
+    expire_after_days = 3
+  }
+}
+
+resource "render_service_environment" "api" {
+  service = render_service.api.id
+  variables = {
+    NODE_ENV = {
+      value = "production"
+    }
+    PORT = {
+      value = "10000"
+    }
+    SECRET_KEY = {
+      generated = true
+    }
+    LOG_LEVEL = {
+      value = "info"
+    }
+    SENTRY_DSN = {
+      value = "https://sentry.example.com/1"
+    }
+  }
+  sensitive_variables = {
+    DATABASE_URL = var.db_connection_string
+    STRIPE_KEY   = var.api_stripe_key
+  }
+}
+
+resource "render_service_custom_domain" "api_api_example_com" {
+  service_id  = render_service.api.id
+  domain_name = "api.example.com"
+}
+
+resource "render_service" "worker" {
+  name        = "worker"
+  type        = "background_worker"
+  repo        = "https://github.com/acme/worker"
+  auto_deploy = false
+  background_worker_details = {
+    env = "docker"
+    disk = {
+      name       = "data"
+      mount_path = "/data"
+      size_gb    = 5
+    }
+  }
+}
+
+resource "render_service_environment" "worker" {
+  service = render_service.worker.id
+  variables = {
+    LOG_LEVEL = {
+      value = "debug"
+    }
+    SENTRY_DSN = {
+      value = "https://sentry.example.com/1"
+    }
+  }
+}
+
+resource "render_service" "site" {
+  name = "site"
+  type = "static_site"
+  repo = "https://github.com/acme/app"
+  static_site_details = {
+    build_command = "yarn build"
+    publish_path  = "dist"
+  }
+  previews {
+    generation = "automatic"
+  }
+}
+
+resource "render_service_headers" "site" {
+  service_id = render_service.site.id
+  headers = [{
+    path  = "/*"
+    name  = "X-Frame-Options"
+    value = "DENY"
+  }]
+}
@@ -61,20 +61,16 @@ resource "render_service_custom_domain" "client-domain" {
 resource "render_service_environment" "api" {
   service = render_service.api.id
 
-  variables = [{
-    key   = "DATABASE_URL"
-    value = render_service.db.private_service_details.url
-  }]
+  variables = {
+    DATABASE_URL = { value = render_service.db.private_service_details.url }
+  }
 }
 
 
 resource "render_service_environment" "client" {
   service = render_service.client.id
 
-  variables = [
-    {
-      key   = "API_URL"
-      value = render_service.api.web_service_details.url
-    }
-  ]
+  variables = {
+    API_URL = { value = render_service.api.web_service_details.url }
+  }
 }
@@ -0,0 +1,3 @@
+data "render_owners" "teams" {
+  type = "team"
+}
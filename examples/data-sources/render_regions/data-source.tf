@@ -0,0 +1,5 @@
+data "render_regions" "all" {}
+
+output "regions" {
+  value = data.render_regions.all.regions[*].id
+}
@@ -0,0 +1,8 @@
+provider "render" {
+  email = "jane@example.com"
+
+  defaults {
+    region = "frankfurt"
+    plan   = "starter"
+  }
+}
@@ -0,0 +1 @@
+terraform import render_blueprint.app <blueprint_id>
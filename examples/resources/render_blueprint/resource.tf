@@ -0,0 +1,11 @@
+resource "render_blueprint" "app" {
+  repo      = "https://github.com/acme/app"
+  branch    = "main"
+  path      = "render.yaml"
+  auto_sync = true
+}
+
+resource "render_service_custom_domain" "app" {
+  service_id  = render_blueprint.app.service_ids[0]
+  domain_name = "app.acme.com"
+}
@@ -0,0 +1 @@
+terraform import render_project.app <project_id>
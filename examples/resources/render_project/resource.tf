@@ -0,0 +1,32 @@
+resource "render_project" "app" {
+  name = "app"
+}
+
+resource "render_project_environment" "production" {
+  project_id = render_project.app.id
+  name       = "Production"
+  protected  = true
+}
+
+resource "render_project_environment" "staging" {
+  project_id                = render_project.app.id
+  name                      = "Staging"
+  network_isolation_enabled = true
+}
+
+resource "render_service" "api" {
+  name           = "api"
+  type           = "web_service"
+  repo           = "https://github.com/acme/api"
+  environment_id = render_project_environment.production.id
+
+  web_service_details = {
+    env    = "node"
+    region = "oregon"
+    plan   = "starter"
+    native = {
+      build_command = "npm ci"
+      start_command = "npm start"
+    }
+  }
+}
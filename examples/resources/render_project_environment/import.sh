@@ -0,0 +1 @@
+terraform import render_project_environment.staging <environment_id>
@@ -0,0 +1,16 @@
+resource "render_project" "app" {
+  name = "app"
+}
+
+# Takes over the environment created with the project
+resource "render_project_environment" "production" {
+  project_id = render_project.app.id
+  name       = "Production"
+  protected  = true
+}
+
+resource "render_project_environment" "staging" {
+  project_id                = render_project.app.id
+  name                      = "Staging"
+  network_isolation_enabled = true
+}
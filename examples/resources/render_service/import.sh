@@ -0,0 +1 @@
+terraform import render_service.api <service_id>
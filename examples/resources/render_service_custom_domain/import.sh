@@ -0,0 +1 @@
+terraform import render_service_custom_domain.www <service_id>:<domain_name>
@@ -0,0 +1,2 @@
+# Imported variables are all read as plain values, including those that were generated.
+terraform import render_service_environment.api <service_id>
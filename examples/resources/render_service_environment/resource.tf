@@ -0,0 +1,13 @@
+resource "render_service_environment" "api" {
+  service = render_service.api.id
+
+  variables = {
+    NODE_ENV   = { value = "production" }
+    SECRET_KEY = { generated = true }
+  }
+
+  # Sent on create and whenever the value changes here, never read back.
+  sensitive_variables = {
+    DATABASE_PASSWORD = var.database_password
+  }
+}
@@ -0,0 +1 @@
+terraform import render_service_headers.client <service_id>
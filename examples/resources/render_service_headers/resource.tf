@@ -0,0 +1,16 @@
+resource "render_service_headers" "client" {
+  service_id = render_service.client.id
+
+  headers = [
+    {
+      path  = "/*"
+      name  = "Strict-Transport-Security"
+      value = "max-age=63072000; includeSubDomains; preload"
+    },
+    {
+      path  = "/static/*"
+      name  = "Cache-Control"
+      value = "public, max-age=31536000, immutable"
+    },
+  ]
+}
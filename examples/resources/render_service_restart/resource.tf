@@ -0,0 +1,16 @@
+resource "render_service_environment" "api" {
+  service = render_service.api.id
+
+  sensitive_variables = {
+    API_SECRET = var.api_secret
+  }
+}
+
+# Restarts the service whenever the secret is rotated
+resource "render_service_restart" "api" {
+  service_id = render_service.api.id
+
+  triggers = {
+    secret = sha256(var.api_secret)
+  }
+}
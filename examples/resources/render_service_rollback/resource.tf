@@ -0,0 +1,16 @@
+variable "rollback_to" {
+  description = "The ID of the deploy to roll back to, e.g. terraform apply -var rollback_to=dep-..."
+  type        = string
+  default     = null
+}
+
+resource "render_service_rollback" "api" {
+  count = var.rollback_to == null ? 0 : 1
+
+  service_id = render_service.api.id
+  deploy_id  = var.rollback_to
+}
+
+output "rollback_status" {
+  value = one(render_service_rollback.api[*].status)
+}
@@ -0,0 +1,1360 @@
+// Package fakerender is an in-memory implementation of the parts of the Render
+// API used by the provider. It follows the render-go OpenAPI shapes, so tests
+// can point the provider at it through `api_url` and run without an account.
+//
+// Lists are always returned as a single page without cursors.
+package fakerender
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackall3n/render-go"
+	"github.com/jackall3n/terraform-provider-render/render/api"
+)
+
+// Server is a fake Render API. The zero value is not usable, use New.
+type Server struct {
+	*httptest.Server
+
+	mu            sync.Mutex
+	ids           map[string]int
+	owners        []render.Owner
+	services      map[string]map[string]interface{}
+	envVars       map[string][]render.EnvVar
+	customDomains map[string][]render.CustomDomain
+	headers       map[string][]render.Header
+	deploys       map[string][]render.Deploy
+	cacheClears   map[string]int
+	events        map[string][]api.Event
+	failing       map[string]bool
+	verifyAfter   int
+	verifications map[string]int
+	blueprints    map[string]*api.Blueprint
+	projects      map[string]*api.Project
+	environments  map[string]*api.Environment
+	requests      []string
+}
+
+// New starts a fake Render API. Close it when done.
+func New() *Server {
+	s := &Server{
+		ids:           map[string]int{},
+		services:      map[string]map[string]interface{}{},
+		envVars:       map[string][]render.EnvVar{},
+		customDomains: map[string][]render.CustomDomain{},
+		headers:       map[string][]render.Header{},
+		deploys:       map[string][]render.Deploy{},
+		cacheClears:   map[string]int{},
+		events:        map[string][]api.Event{},
+		failing:       map[string]bool{},
+		verifyAfter:   1,
+		verifications: map[string]int{},
+		blueprints:    map[string]*api.Blueprint{},
+		projects:      map[string]*api.Project{},
+		environments:  map[string]*api.Environment{},
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+// AddOwner registers an owner, returned by the owners endpoints.
+func (s *Server) AddOwner(owner render.Owner) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.owners = append(s.owners, owner)
+}
+
+// AddService stores a service as if it was created outside of Terraform and returns its ID.
+func (s *Server) AddService(service api.ServicePOST) (string, error) {
+	var body map[string]interface{}
+
+	if err := remarshal(service, &body); err != nil {
+		return "", err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.createService(body)["id"].(string), nil
+}
+
+// Service returns the stored service.
+func (s *Server) Service(id string) (api.Service, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.services[id]
+
+	if !ok {
+		return api.Service{}, false
+	}
+
+	var service api.Service
+	_ = remarshal(stored, &service)
+
+	return service, true
+}
+
+// DeleteService removes a service and everything attached to it, e.g. to simulate drift.
+func (s *Server) DeleteService(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.deleteService(id)
+}
+
+// EnvVars returns the variables of a service.
+func (s *Server) EnvVars(serviceId string) []render.EnvVar {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]render.EnvVar{}, s.envVars[serviceId]...)
+}
+
+// SetEnvVars replaces the variables of a service, e.g. to simulate drift.
+func (s *Server) SetEnvVars(serviceId string, envVars []render.EnvVar) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.envVars[serviceId] = append([]render.EnvVar{}, envVars...)
+}
+
+// CustomDomains returns the custom domains of a service.
+func (s *Server) CustomDomains(serviceId string) []render.CustomDomain {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]render.CustomDomain{}, s.customDomains[serviceId]...)
+}
+
+// AddCustomDomain adds a custom domain to a service as if it was added outside of Terraform.
+func (s *Server) AddCustomDomain(serviceId string, name string) render.CustomDomain {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	domain := s.newCustomDomain(serviceId, name)
+	s.customDomains[serviceId] = append(s.customDomains[serviceId], domain)
+
+	return domain
+}
+
+// Headers returns the headers of a service.
+func (s *Server) Headers(serviceId string) []render.Header {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]render.Header{}, s.headers[serviceId]...)
+}
+
+// SetHeaders replaces the headers of a service.
+func (s *Server) SetHeaders(serviceId string, headers []render.Header) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.headers[serviceId] = append([]render.Header{}, headers...)
+}
+
+// Deploys returns the deploys of a service, newest first.
+func (s *Server) Deploys(serviceId string) []render.Deploy {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]render.Deploy{}, s.deploys[serviceId]...)
+}
+
+// DeleteDeploy removes a deploy of a service, as Render does with old deploys.
+func (s *Server) DeleteDeploy(serviceId string, deployId string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deploys := s.deploys[serviceId]
+
+	for i, deploy := range deploys {
+		if deploy.Id == deployId {
+			s.deploys[serviceId] = append(deploys[:i:i], deploys[i+1:]...)
+			return
+		}
+	}
+}
+
+// CacheClears returns how many deploys of a service cleared the build cache.
+func (s *Server) CacheClears(serviceId string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.cacheClears[serviceId]
+}
+
+// Events returns the events of a service, newest first.
+func (s *Server) Events(serviceId string) []api.Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]api.Event{}, s.events[serviceId]...)
+}
+
+// SetFailing makes the restarts and deploys of a service fail, e.g. after a bad release.
+func (s *Server) SetFailing(serviceId string, failing bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failing[serviceId] = failing
+}
+
+// VerifyNever keeps custom domains unverified, e.g. when their DNS records are missing.
+const VerifyNever = 0
+
+// SetVerifyAfter makes custom domains verified on their polls-th verify request, the first by default,
+// or never with VerifyNever.
+func (s *Server) SetVerifyAfter(polls int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.verifyAfter = polls
+}
+
+// AddBlueprint stores a blueprint as if it was created through "New Blueprint Instance" and returns its ID.
+// The API can't create blueprints, so this is the only way to get one.
+func (s *Server) AddBlueprint(blueprint api.Blueprint) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	blueprint.Id = s.nextID("exs")
+
+	if blueprint.Status == "" {
+		blueprint.Status = api.BlueprintInSync
+	}
+
+	if blueprint.Branch == "" {
+		blueprint.Branch = "main"
+	}
+
+	if blueprint.Path == "" {
+		blueprint.Path = "render.yaml"
+	}
+
+	blueprint.Resources = append([]api.BlueprintResource{}, blueprint.Resources...)
+	s.blueprints[blueprint.Id] = &blueprint
+
+	return blueprint.Id
+}
+
+// Blueprint returns the stored blueprint.
+func (s *Server) Blueprint(id string) (api.Blueprint, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	blueprint, ok := s.blueprints[id]
+
+	if !ok {
+		return api.Blueprint{}, false
+	}
+
+	return *blueprint, true
+}
+
+// AddProject stores a project created outside of Terraform, e.g. in the dashboard, and returns its ID.
+func (s *Server) AddProject(project api.ProjectPOST) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.createProject(project).Id
+}
+
+// Project returns the stored project.
+func (s *Server) Project(id string) (api.Project, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	project, ok := s.projects[id]
+
+	if !ok {
+		return api.Project{}, false
+	}
+
+	return *project, true
+}
+
+// Environment returns the stored environment, with the services in it.
+func (s *Server) Environment(id string) (api.Environment, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.environments[id]; !ok {
+		return api.Environment{}, false
+	}
+
+	return s.environment(id), true
+}
+
+// Requests returns every request received so far as "METHOD /path".
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string{}, s.requests...)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	switch {
+	case parts[0] == "owners":
+		s.serveOwners(w, r, parts[1:])
+	case parts[0] == "blueprints" && len(parts) <= 2:
+		s.serveBlueprints(w, r, parts[1:])
+	case parts[0] == "projects" && len(parts) <= 2:
+		s.serveProjects(w, r, parts[1:])
+	case parts[0] == "environments" && len(parts) <= 3:
+		s.serveEnvironments(w, r, parts[1:])
+	case parts[0] == "services" && len(parts) <= 2:
+		s.serveServices(w, r, parts[1:])
+	case parts[0] == "services":
+		if _, ok := s.services[parts[1]]; !ok {
+			notFound(w, "service")
+			return
+		}
+
+		switch parts[2] {
+		case "env-vars":
+			s.serveEnvVars(w, r, parts[1])
+		case "custom-domains":
+			s.serveCustomDomains(w, r, parts[1], parts[3:])
+		case "headers":
+			s.serveHeaders(w, r, parts[1])
+		case "deploys":
+			s.serveDeploys(w, r, parts[1], parts[3:])
+		case "restart":
+			s.serveRestart(w, r, parts[1])
+		case "rollback":
+			s.serveRollback(w, r, parts[1])
+		case "events":
+			s.serveEvents(w, r, parts[1])
+		default:
+			notFound(w, "resource")
+		}
+	default:
+		notFound(w, "resource")
+	}
+}
+
+func (s *Server) serveOwners(w http.ResponseWriter, r *http.Request, parts []string) {
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w)
+		return
+	}
+
+	if len(parts) == 1 {
+		for _, owner := range s.owners {
+			if owner.Id == parts[0] {
+				respond(w, http.StatusOK, owner)
+				return
+			}
+		}
+
+		notFound(w, "owner")
+		return
+	}
+
+	query := r.URL.Query()
+	items := []map[string]interface{}{}
+
+	for _, owner := range s.owners {
+		if !matches(query["email"], owner.Email) || !matches(query["name"], owner.Name) {
+			continue
+		}
+
+		items = append(items, map[string]interface{}{"owner": owner})
+	}
+
+	respond(w, http.StatusOK, items)
+}
+
+func (s *Server) serveBlueprints(w http.ResponseWriter, r *http.Request, parts []string) {
+	if len(parts) == 0 {
+		if r.Method != http.MethodGet {
+			methodNotAllowed(w)
+			return
+		}
+
+		query := r.URL.Query()
+		ids := make([]string, 0, len(s.blueprints))
+
+		for id := range s.blueprints {
+			ids = append(ids, id)
+		}
+
+		sort.Strings(ids)
+
+		items := []map[string]interface{}{}
+
+		for _, id := range ids {
+			blueprint := s.syncBlueprint(id)
+
+			if !matches(query["ownerId"], &blueprint.OwnerId) {
+				continue
+			}
+
+			items = append(items, map[string]interface{}{"blueprint": blueprint})
+		}
+
+		respond(w, http.StatusOK, items)
+		return
+	}
+
+	if _, ok := s.blueprints[parts[0]]; !ok {
+		notFound(w, "blueprint")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		respond(w, http.StatusOK, s.syncBlueprint(parts[0]))
+	case http.MethodPatch:
+		var body api.BlueprintPATCH
+
+		if !decode(w, r, &body) {
+			return
+		}
+
+		blueprint := s.blueprints[parts[0]]
+
+		if body.Name != nil {
+			blueprint.Name = *body.Name
+		}
+
+		if body.AutoSync != nil {
+			blueprint.AutoSync = *body.AutoSync
+		}
+
+		if body.Path != nil {
+			blueprint.Path = *body.Path
+		}
+
+		// The sync finishes by the next read.
+		blueprint.Status = api.BlueprintSyncing
+
+		respond(w, http.StatusOK, blueprint)
+	case http.MethodDelete:
+		delete(s.blueprints, parts[0])
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		methodNotAllowed(w)
+	}
+}
+
+// syncBlueprint finishes a pending sync of a blueprint and returns it.
+func (s *Server) syncBlueprint(id string) *api.Blueprint {
+	blueprint := s.blueprints[id]
+
+	if blueprint.Status == api.BlueprintSyncing {
+		now := time.Now().UTC().Truncate(time.Second)
+		blueprint.Status = api.BlueprintInSync
+		blueprint.LastSync = &now
+	}
+
+	return blueprint
+}
+
+func (s *Server) serveProjects(w http.ResponseWriter, r *http.Request, parts []string) {
+	if len(parts) == 0 {
+		if r.Method != http.MethodPost {
+			methodNotAllowed(w)
+			return
+		}
+
+		var body api.ProjectPOST
+
+		if !decode(w, r, &body) {
+			return
+		}
+
+		switch {
+		case body.Name == "":
+			badRequest(w, "name is required")
+			return
+		case body.OwnerId == "":
+			badRequest(w, "ownerId is required")
+			return
+		case len(body.Environments) == 0:
+			badRequest(w, "a project needs at least one environment")
+			return
+		}
+
+		respond(w, http.StatusCreated, s.createProject(body))
+		return
+	}
+
+	project, ok := s.projects[parts[0]]
+
+	if !ok {
+		notFound(w, "project")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		respond(w, http.StatusOK, project)
+	case http.MethodPatch:
+		var body api.ProjectPATCH
+
+		if !decode(w, r, &body) {
+			return
+		}
+
+		if body.Name != nil {
+			project.Name = *body.Name
+		}
+
+		respond(w, http.StatusOK, project)
+	case http.MethodDelete:
+		for _, id := range project.EnvironmentIds {
+			s.deleteEnvironment(id)
+		}
+
+		delete(s.projects, parts[0])
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		methodNotAllowed(w)
+	}
+}
+
+func (s *Server) serveEnvironments(w http.ResponseWriter, r *http.Request, parts []string) {
+	if len(parts) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			project, ok := s.projects[r.URL.Query().Get("projectId")]
+
+			if !ok {
+				badRequest(w, "projectId is required")
+				return
+			}
+
+			items := []map[string]interface{}{}
+
+			for _, id := range project.EnvironmentIds {
+				items = append(items, map[string]interface{}{"environment": s.environment(id)})
+			}
+
+			respond(w, http.StatusOK, items)
+		case http.MethodPost:
+			var body api.EnvironmentPOST
+
+			if !decode(w, r, &body) {
+				return
+			}
+
+			if _, ok := s.projects[body.ProjectId]; !ok {
+				notFound(w, "project")
+				return
+			}
+
+			if body.Name == "" {
+				badRequest(w, "name is required")
+				return
+			}
+
+			respond(w, http.StatusCreated, s.environment(s.createEnvironment(body.ProjectId, body.EnvironmentInput)))
+		default:
+			methodNotAllowed(w)
+		}
+
+		return
+	}
+
+	environment, ok := s.environments[parts[0]]
+
+	if !ok {
+		notFound(w, "environment")
+		return
+	}
+
+	if len(parts) == 2 {
+		if parts[1] != "resources" {
+			notFound(w, "resource")
+			return
+		}
+
+		var ids []string
+
+		switch r.Method {
+		case http.MethodPost:
+			var body struct {
+				ResourceIds []string `json:"resourceIds"`
+			}
+
+			if !decode(w, r, &body) {
+				return
+			}
+
+			ids = body.ResourceIds
+		case http.MethodDelete:
+			ids = r.URL.Query()["resourceIds"]
+		default:
+			methodNotAllowed(w)
+			return
+		}
+
+		for _, id := range ids {
+			if _, ok := s.services[id]; !ok {
+				notFound(w, "service")
+				return
+			}
+		}
+
+		for _, id := range ids {
+			if r.Method == http.MethodPost {
+				s.services[id]["environmentId"] = environment.Id
+			} else if s.services[id]["environmentId"] == environment.Id {
+				delete(s.services[id], "environmentId")
+			}
+		}
+
+		respond(w, http.StatusOK, s.environment(environment.Id))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		respond(w, http.StatusOK, s.environment(environment.Id))
+	case http.MethodPatch:
+		var body api.EnvironmentPATCH
+
+		if !decode(w, r, &body) {
+			return
+		}
+
+		if body.Name != nil {
+			environment.Name = *body.Name
+		}
+
+		if body.ProtectedStatus != nil {
+			environment.ProtectedStatus = *body.ProtectedStatus
+		}
+
+		if body.NetworkIsolationEnabled != nil {
+			environment.NetworkIsolationEnabled = *body.NetworkIsolationEnabled
+		}
+
+		respond(w, http.StatusOK, s.environment(environment.Id))
+	case http.MethodDelete:
+		s.deleteEnvironment(environment.Id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		methodNotAllowed(w)
+	}
+}
+
+func (s *Server) createProject(body api.ProjectPOST) *api.Project {
+	project := &api.Project{Id: s.nextID("prj"), Name: body.Name, EnvironmentIds: []string{}}
+
+	for _, owner := range s.owners {
+		if owner.Id == body.OwnerId {
+			project.Owner = &render.Owner{Id: owner.Id, Name: owner.Name, Email: owner.Email}
+		}
+	}
+
+	s.projects[project.Id] = project
+
+	for _, environment := range body.Environments {
+		s.createEnvironment(project.Id, environment)
+	}
+
+	return project
+}
+
+func (s *Server) createEnvironment(projectId string, input api.EnvironmentInput) string {
+	environment := &api.Environment{
+		Id:              s.nextID("evm"),
+		Name:            input.Name,
+		ProjectId:       projectId,
+		ProtectedStatus: api.Unprotected,
+	}
+
+	if input.ProtectedStatus != "" {
+		environment.ProtectedStatus = input.ProtectedStatus
+	}
+
+	if input.NetworkIsolationEnabled != nil {
+		environment.NetworkIsolationEnabled = *input.NetworkIsolationEnabled
+	}
+
+	s.environments[environment.Id] = environment
+
+	project := s.projects[projectId]
+	project.EnvironmentIds = append(project.EnvironmentIds, environment.Id)
+
+	return environment.Id
+}
+
+// environment returns a copy of an environment listing the services in it.
+func (s *Server) environment(id string) api.Environment {
+	environment := *s.environments[id]
+	environment.ServiceIds = []string{}
+
+	for _, serviceId := range s.sortedServiceIds() {
+		if s.services[serviceId]["environmentId"] == id {
+			environment.ServiceIds = append(environment.ServiceIds, serviceId)
+		}
+	}
+
+	return environment
+}
+
+// deleteEnvironment removes an environment. Its services are kept, outside of any environment.
+func (s *Server) deleteEnvironment(id string) {
+	environment := s.environments[id]
+
+	for _, service := range s.services {
+		if service["environmentId"] == id {
+			delete(service, "environmentId")
+		}
+	}
+
+	if project, ok := s.projects[environment.ProjectId]; ok {
+		ids := []string{}
+
+		for _, environmentId := range project.EnvironmentIds {
+			if environmentId != id {
+				ids = append(ids, environmentId)
+			}
+		}
+
+		project.EnvironmentIds = ids
+	}
+
+	delete(s.environments, id)
+}
+
+func (s *Server) serveServices(w http.ResponseWriter, r *http.Request, parts []string) {
+	if len(parts) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			query := r.URL.Query()
+			items := []map[string]interface{}{}
+
+			for _, id := range s.sortedServiceIds() {
+				service := s.services[id]
+
+				if !matchesValue(query["ownerId"], service["ownerId"]) ||
+					!matchesValue(query["name"], service["name"]) ||
+					!matchesValue(query["type"], service["type"]) {
+					continue
+				}
+
+				items = append(items, map[string]interface{}{"service": service})
+			}
+
+			respond(w, http.StatusOK, items)
+		case http.MethodPost:
+			var body map[string]interface{}
+
+			if !decode(w, r, &body) {
+				return
+			}
+
+			for _, field := range []string{"name", "ownerId", "repo", "type"} {
+				if value, _ := body[field].(string); value == "" {
+					badRequest(w, fmt.Sprintf("%s is required", field))
+					return
+				}
+			}
+
+			service := s.createService(body)
+
+			respond(w, http.StatusCreated, map[string]interface{}{
+				"service":  service,
+				"deployId": s.createDeploy(service["id"].(string), nil).Id,
+			})
+		default:
+			methodNotAllowed(w)
+		}
+
+		return
+	}
+
+	service, ok := s.services[parts[0]]
+
+	if !ok {
+		notFound(w, "service")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		respond(w, http.StatusOK, service)
+	case http.MethodPatch:
+		var body map[string]interface{}
+
+		if !decode(w, r, &body) {
+			return
+		}
+
+		details, _ := body["serviceDetails"].(map[string]interface{})
+		delete(body, "serviceDetails")
+
+		merge(service, body)
+
+		if details != nil {
+			current, _ := service["serviceDetails"].(map[string]interface{})
+
+			if current == nil {
+				current = map[string]interface{}{}
+				service["serviceDetails"] = current
+			}
+
+			merge(current, responseDetails(details))
+		}
+
+		service["updatedAt"] = time.Now().UTC().Format(time.RFC3339)
+
+		respond(w, http.StatusOK, service)
+	case http.MethodDelete:
+		s.deleteService(parts[0])
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		methodNotAllowed(w)
+	}
+}
+
+func (s *Server) serveEnvVars(w http.ResponseWriter, r *http.Request, serviceId string) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		var body []map[string]string
+
+		if !decode(w, r, &body) {
+			return
+		}
+
+		envVars := []render.EnvVar{}
+
+		for _, item := range body {
+			value, ok := item["value"]
+
+			if generate, generated := item["generateValue"]; generated {
+				if generate != string(render.EnvVarKeyGenerateValueGenerateValueYes) {
+					badRequest(w, "invalid JSON")
+					return
+				}
+
+				value = s.nextID("generated")
+			} else if !ok {
+				badRequest(w, "value is required")
+				return
+			}
+
+			envVars = append(envVars, render.EnvVar{Key: item["key"], Value: value})
+		}
+
+		s.envVars[serviceId] = envVars
+	default:
+		methodNotAllowed(w)
+		return
+	}
+
+	items := []map[string]interface{}{}
+
+	for _, envVar := range s.envVars[serviceId] {
+		items = append(items, map[string]interface{}{"envVar": envVar})
+	}
+
+	respond(w, http.StatusOK, items)
+}
+
+func (s *Server) serveCustomDomains(w http.ResponseWriter, r *http.Request, serviceId string, parts []string) {
+	domains := s.customDomains[serviceId]
+
+	if len(parts) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			items := []map[string]interface{}{}
+
+			for _, domain := range domains {
+				items = append(items, map[string]interface{}{"customDomain": domain})
+			}
+
+			respond(w, http.StatusOK, items)
+		case http.MethodPost:
+			var body render.CreateCustomDomainJSONBody
+
+			if !decode(w, r, &body) {
+				return
+			}
+
+			for _, domain := range domains {
+				if *domain.Name == body.Name {
+					respond(w, http.StatusConflict, render.Error{Message: ptr("custom domain already exists")})
+					return
+				}
+			}
+
+			domain := s.newCustomDomain(serviceId, body.Name)
+			s.customDomains[serviceId] = append(domains, domain)
+
+			respond(w, http.StatusCreated, []render.CustomDomain{domain})
+		default:
+			methodNotAllowed(w)
+		}
+
+		return
+	}
+
+	index := -1
+
+	for i, domain := range domains {
+		if *domain.Name == parts[0] || *domain.Id == parts[0] {
+			index = i
+		}
+	}
+
+	if index < 0 {
+		notFound(w, "custom domain")
+		return
+	}
+
+	switch {
+	case len(parts) == 2 && parts[1] == "verify" && r.Method == http.MethodPost:
+		s.verifications[*domains[index].Id]++
+
+		if s.verifyAfter != VerifyNever && s.verifications[*domains[index].Id] >= s.verifyAfter {
+			status := render.CustomDomainVerificationStatusVerified
+			domains[index].VerificationStatus = &status
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		respond(w, http.StatusOK, domains[index])
+	case len(parts) == 1 && r.Method == http.MethodDelete:
+		s.customDomains[serviceId] = append(domains[:index:index], domains[index+1:]...)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		methodNotAllowed(w)
+	}
+}
+
+func (s *Server) serveHeaders(w http.ResponseWriter, r *http.Request, serviceId string) {
+	switch r.Method {
+	case http.MethodGet:
+		items := []map[string]interface{}{}
+
+		for _, header := range s.headers[serviceId] {
+			items = append(items, map[string]interface{}{"headers": header})
+		}
+
+		respond(w, http.StatusOK, items)
+	case http.MethodPut:
+		var body []render.Header
+
+		if !decode(w, r, &body) {
+			return
+		}
+
+		if s.services[serviceId]["type"] != string(render.StaticSite) {
+			badRequest(w, "headers can only be set on static sites")
+			return
+		}
+
+		s.headers[serviceId] = append([]render.Header{}, body...)
+
+		respond(w, http.StatusOK, s.headers[serviceId])
+	default:
+		methodNotAllowed(w)
+	}
+}
+
+func (s *Server) serveDeploys(w http.ResponseWriter, r *http.Request, serviceId string, parts []string) {
+	if len(parts) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			items := []map[string]interface{}{}
+
+			for _, deploy := range s.deploys[serviceId] {
+				items = append(items, map[string]interface{}{"deploy": deploy})
+			}
+
+			respond(w, http.StatusOK, items)
+		case http.MethodPost:
+			var body render.CreateDeployJSONBody
+
+			if !decode(w, r, &body) {
+				return
+			}
+
+			respond(w, http.StatusCreated, s.createDeploy(serviceId, body.ClearCache))
+		default:
+			methodNotAllowed(w)
+		}
+
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w)
+		return
+	}
+
+	for _, deploy := range s.deploys[serviceId] {
+		if deploy.Id == parts[0] {
+			respond(w, http.StatusOK, deploy)
+			return
+		}
+	}
+
+	notFound(w, "deploy")
+}
+
+func (s *Server) serveRestart(w http.ResponseWriter, r *http.Request, serviceId string) {
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w)
+		return
+	}
+
+	// Restarts finish right away, with the instances becoming healthy unless the service is failing.
+	eventType := api.ServerAvailable
+
+	if s.failing[serviceId] {
+		eventType = api.ServerFailed
+	}
+
+	s.addEvent(serviceId, eventType)
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) serveRollback(w http.ResponseWriter, r *http.Request, serviceId string) {
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w)
+		return
+	}
+
+	var body struct {
+		DeployId string `json:"deployId"`
+	}
+
+	if !decode(w, r, &body) {
+		return
+	}
+
+	for _, deploy := range s.deploys[serviceId] {
+		if deploy.Id == body.DeployId {
+			respond(w, http.StatusCreated, s.createDeploy(serviceId, nil))
+			return
+		}
+	}
+
+	notFound(w, "deploy")
+}
+
+func (s *Server) serveEvents(w http.ResponseWriter, r *http.Request, serviceId string) {
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w)
+		return
+	}
+
+	items := []map[string]interface{}{}
+
+	for _, event := range s.events[serviceId] {
+		if matchesValue(r.URL.Query()["type"], string(event.Type)) {
+			items = append(items, map[string]interface{}{"event": event, "cursor": event.Id})
+		}
+	}
+
+	respond(w, http.StatusOK, items)
+}
+
+func (s *Server) createService(body map[string]interface{}) map[string]interface{} {
+	id := s.nextID("srv")
+	now := time.Now().UTC().Format(time.RFC3339)
+	name, _ := body["name"].(string)
+	slug := strings.ToLower(strings.ReplaceAll(name, " ", "-")) + "-" + id[len(id)-4:]
+
+	service := map[string]interface{}{}
+	merge(service, body)
+	delete(service, "envVars")
+	delete(service, "secretFiles")
+
+	service["id"] = id
+	service["slug"] = slug
+	service["createdAt"] = now
+	service["updatedAt"] = now
+	service["suspended"] = string(render.ServiceSuspendedNotSuspended)
+
+	if _, ok := service["autoDeploy"]; !ok {
+		service["autoDeploy"] = string(render.ServiceAutoDeployYes)
+	}
+
+	if _, ok := service["branch"]; !ok {
+		service["branch"] = "main"
+	}
+
+	details, _ := body["serviceDetails"].(map[string]interface{})
+	details = responseDetails(details)
+
+	switch render.ServiceType(service["type"].(string)) {
+	case render.WebService, render.PrivateService, render.BackgroundWorker, render.CronJob:
+		setDefault(details, "region", string(render.Oregon))
+		setDefault(details, "plan", "starter")
+	}
+
+	switch render.ServiceType(service["type"].(string)) {
+	case render.WebService, render.PrivateService, render.BackgroundWorker:
+		setDefault(details, "maxShutdownDelaySeconds", 30)
+	}
+
+	switch render.ServiceType(service["type"].(string)) {
+	case render.WebService, render.StaticSite:
+		details["url"] = fmt.Sprintf("https://%s.onrender.com", slug)
+	case render.PrivateService:
+		details["url"] = fmt.Sprintf("%s:10000", slug)
+	}
+
+	service["serviceDetails"] = details
+
+	s.services[id] = service
+
+	if envVars, ok := body["envVars"].([]interface{}); ok {
+		for _, item := range envVars {
+			if envVar, ok := item.(map[string]interface{}); ok {
+				key, _ := envVar["key"].(string)
+				value, _ := envVar["value"].(string)
+				s.envVars[id] = append(s.envVars[id], render.EnvVar{Key: key, Value: value})
+			}
+		}
+	}
+
+	return service
+}
+
+func (s *Server) deleteService(id string) {
+	delete(s.services, id)
+	delete(s.envVars, id)
+	delete(s.customDomains, id)
+	delete(s.headers, id)
+	delete(s.deploys, id)
+	delete(s.cacheClears, id)
+	delete(s.events, id)
+	delete(s.failing, id)
+}
+
+func (s *Server) createDeploy(serviceId string, clearCache *render.CreateDeployJSONBodyClearCache) render.Deploy {
+	now := time.Now().UTC()
+	status := render.Live
+
+	if clearCache != nil && *clearCache == render.Clear {
+		s.cacheClears[serviceId]++
+	}
+
+	// Deploys of failing services never go live.
+	if s.failing[serviceId] {
+		status = render.UpdateFailed
+	}
+
+	deploy := render.Deploy{
+		Id:         s.nextID("dep"),
+		Status:     &status,
+		CreatedAt:  &now,
+		UpdatedAt:  &now,
+		FinishedAt: &now,
+	}
+
+	// A new live deploy deactivates the previous one.
+	for i := range s.deploys[serviceId] {
+		if status == render.Live && *s.deploys[serviceId][i].Status == render.Live {
+			deactivated := render.Deactivated
+			s.deploys[serviceId][i].Status = &deactivated
+		}
+	}
+
+	s.deploys[serviceId] = append([]render.Deploy{deploy}, s.deploys[serviceId]...)
+
+	return deploy
+}
+
+func (s *Server) addEvent(serviceId string, eventType api.EventType) {
+	event := api.Event{
+		Id:        s.nextID("evt"),
+		Timestamp: time.Now().UTC(),
+		ServiceId: serviceId,
+		Type:      eventType,
+	}
+
+	s.events[serviceId] = append([]api.Event{event}, s.events[serviceId]...)
+}
+
+func (s *Server) newCustomDomain(serviceId string, name string) render.CustomDomain {
+	now := time.Now().UTC()
+	labels := strings.Split(name, ".")
+	domainType := render.CustomDomainDomainTypeSubdomain
+
+	if len(labels) == 2 {
+		domainType = render.CustomDomainDomainTypeApex
+	}
+
+	status := render.CustomDomainVerificationStatusUnverified
+	serviceName, _ := s.services[serviceId]["name"].(string)
+
+	domain := render.CustomDomain{
+		Id:                 ptr(s.nextID("cdm")),
+		Name:               ptr(name),
+		DomainType:         &domainType,
+		PublicSuffix:       ptr(labels[len(labels)-1]),
+		VerificationStatus: &status,
+		CreatedAt:          &now,
+	}
+
+	domain.Server = &struct {
+		Id   *string `json:"id,omitempty"`
+		Name *string `json:"name,omitempty"`
+	}{Id: ptr(serviceId), Name: ptr(serviceName)}
+
+	if len(labels) == 3 && labels[0] == "www" {
+		domain.RedirectForName = ptr(strings.Join(labels[1:], "."))
+	}
+
+	return domain
+}
+
+func (s *Server) nextID(prefix string) string {
+	s.ids[prefix]++
+
+	return fmt.Sprintf("%s-%08d", prefix, s.ids[prefix])
+}
+
+func (s *Server) sortedServiceIds() []string {
+	ids := make([]string, 0, len(s.services))
+
+	for id := range s.services {
+		ids = append(ids, id)
+	}
+
+	sort.Strings(ids)
+
+	return ids
+}
+
+// responseDetails converts POST/PATCH service details to the shape the API returns.
+func responseDetails(details map[string]interface{}) map[string]interface{} {
+	result := map[string]interface{}{}
+	merge(result, details)
+
+	if disk, ok := result["disk"].(map[string]interface{}); ok {
+		result["disk"] = map[string]interface{}{
+			"id":   "dsk-" + fmt.Sprint(disk["name"]),
+			"name": disk["name"],
+		}
+	}
+
+	return result
+}
+
+// merge copies src into dst, merging nested objects.
+func merge(dst map[string]interface{}, src map[string]interface{}) {
+	for key, value := range src {
+		nested, ok := value.(map[string]interface{})
+		current, isMap := dst[key].(map[string]interface{})
+
+		if ok && isMap {
+			merge(current, nested)
+			continue
+		}
+
+		if ok {
+			copied := map[string]interface{}{}
+			merge(copied, nested)
+			value = copied
+		}
+
+		dst[key] = value
+	}
+}
+
+func setDefault(m map[string]interface{}, key string, value interface{}) {
+	if _, ok := m[key]; !ok {
+		m[key] = value
+	}
+}
+
+func matches(filter []string, value *string) bool {
+	if len(filter) == 0 {
+		return true
+	}
+
+	if value == nil {
+		return false
+	}
+
+	for _, f := range filter {
+		if f == *value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchesValue(filter []string, value interface{}) bool {
+	str, ok := value.(string)
+
+	if !ok {
+		return len(filter) == 0
+	}
+
+	return matches(filter, &str)
+}
+
+func remarshal(in interface{}, out interface{}) error {
+	b, err := json.Marshal(in)
+
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, out)
+}
+
+func decode(w http.ResponseWriter, r *http.Request, out interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(out); err != nil {
+		badRequest(w, "invalid JSON: "+err.Error())
+		return false
+	}
+
+	return true
+}
+
+func respond(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func notFound(w http.ResponseWriter, what string) {
+	respond(w, http.StatusNotFound, render.Error{Id: ptr("not-found"), Message: ptr(what + " not found")})
+}
+
+func badRequest(w http.ResponseWriter, message string) {
+	respond(w, http.StatusBadRequest, render.Error{Id: ptr("bad-request"), Message: ptr(message)})
+}
+
+func methodNotAllowed(w http.ResponseWriter) {
+	respond(w, http.StatusMethodNotAllowed, render.Error{Message: ptr("method not allowed")})
+}
+
+func ptr(value string) *string {
+	return &value
+}
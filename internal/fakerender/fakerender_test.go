@@ -0,0 +1,201 @@
+package fakerender
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/jackall3n/render-go"
+	"github.com/jackall3n/terraform-provider-render/render/api"
+)
+
+func newClient(t *testing.T) (*Server, *render.ClientWithResponses) {
+	t.Helper()
+
+	server := New()
+	t.Cleanup(server.Close)
+
+	client, err := render.NewClientWithResponses(server.URL)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return server, client
+}
+
+func TestServiceLifecycle(t *testing.T) {
+	ctx := context.Background()
+	server, client := newClient(t)
+
+	details := render.ServicePOST_ServiceDetails{}
+	plan := render.WebServiceDetailsPOSTPlanStarter
+
+	if err := details.FromWebServiceDetailsPOST(render.WebServiceDetailsPOST{Env: render.Node, Plan: &plan}); err != nil {
+		t.Fatal(err)
+	}
+
+	created, err := client.CreateServiceWithResponse(ctx, render.ServicePOST{
+		Name:           "api",
+		OwnerId:        "usr-1",
+		Repo:           "https://github.com/render-examples/express-hello-world",
+		Type:           render.WebService,
+		ServiceDetails: &details,
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if created.StatusCode() != http.StatusCreated {
+		t.Fatalf("expected 201, got %s %s", created.Status(), created.Body)
+	}
+
+	id := *created.JSON201.Service.Id
+
+	name := "renamed"
+	updated, err := client.UpdateServiceWithResponse(ctx, id, render.ServicePATCH{Name: &name})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if *updated.JSON200.Name != name {
+		t.Errorf("expected name %s, got %s", name, *updated.JSON200.Name)
+	}
+
+	read, err := client.GetServiceWithResponse(ctx, id)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	web, err := read.JSON200.ServiceDetails.AsWebServiceDetails()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if web.Url == nil || *web.Region != render.Oregon || *web.Env != render.Node {
+		t.Errorf("unexpected service details %+v", web)
+	}
+
+	if len(server.Deploys(id)) != 1 {
+		t.Errorf("expected creating a service to deploy it")
+	}
+
+	if _, err := client.DeleteServiceWithResponse(ctx, id); err != nil {
+		t.Fatal(err)
+	}
+
+	missing, err := client.GetServiceWithResponse(ctx, id)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if missing.StatusCode() != http.StatusNotFound {
+		t.Errorf("expected 404 after delete, got %s", missing.Status())
+	}
+}
+
+func TestEnvVars(t *testing.T) {
+	ctx := context.Background()
+	server, client := newClient(t)
+
+	id, err := server.AddService(api.ServicePOST{ServicePOST: render.ServicePOST{Name: "api", OwnerId: "usr-1", Repo: "repo", Type: render.WebService}})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plain := render.EnvVarsPATCH_Item{}
+	generated := render.EnvVarsPATCH_Item{}
+
+	_ = plain.FromEnvVarKeyValue(render.EnvVarKeyValue{Key: "A", Value: "1"})
+	_ = generated.FromEnvVarKeyGenerateValue(render.EnvVarKeyGenerateValue{Key: "B", GenerateValue: render.EnvVarKeyGenerateValueGenerateValueYes})
+
+	response, err := client.UpdateEnvVarsForServiceWithResponse(ctx, id, render.EnvVarsPATCH{plain, generated})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if response.StatusCode() != http.StatusOK || len(*response.JSON200) != 2 {
+		t.Fatalf("unexpected response %s %s", response.Status(), response.Body)
+	}
+
+	envVars := server.EnvVars(id)
+
+	if envVars[0].Value != "1" || envVars[1].Value == "" {
+		t.Errorf("unexpected variables %v", envVars)
+	}
+}
+
+func TestCustomDomains(t *testing.T) {
+	ctx := context.Background()
+	server, client := newClient(t)
+
+	id, err := server.AddService(api.ServicePOST{ServicePOST: render.ServicePOST{Name: "site", OwnerId: "usr-1", Repo: "repo", Type: render.StaticSite}})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	created, err := client.CreateCustomDomainWithResponse(ctx, id, render.CreateCustomDomainJSONRequestBody{Name: "www.example.com"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	domain := (*created.JSON201)[0]
+
+	if *domain.DomainType != render.CustomDomainDomainTypeSubdomain || *domain.RedirectForName != "example.com" {
+		t.Errorf("unexpected domain %+v", domain)
+	}
+
+	if _, err := client.RefreshCustomDomainWithResponse(ctx, id, "www.example.com"); err != nil {
+		t.Fatal(err)
+	}
+
+	read, err := client.GetCustomDomainWithResponse(ctx, id, "www.example.com")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if *read.JSON200.VerificationStatus != render.CustomDomainVerificationStatusVerified {
+		t.Errorf("expected the domain to be verified, got %s", *read.JSON200.VerificationStatus)
+	}
+
+	deleted, err := client.DeleteCustomDomainWithResponse(ctx, id, "www.example.com")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if deleted.StatusCode() != http.StatusNoContent || len(server.CustomDomains(id)) != 0 {
+		t.Errorf("expected the domain to be deleted, got %s", deleted.Status())
+	}
+}
+
+func TestOwners(t *testing.T) {
+	ctx := context.Background()
+	server, client := newClient(t)
+
+	email := "jane@example.com"
+	server.AddOwner(render.Owner{Id: "usr-1", Email: &email})
+	server.AddOwner(render.Owner{Id: "tea-1"})
+
+	response, err := client.GetOwnersWithResponse(ctx, &render.GetOwnersParams{Email: &[]string{email}})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	owners := *response.JSON200
+
+	if len(owners) != 1 || owners[0].Owner.Id != "usr-1" {
+		t.Errorf("unexpected owners %v", owners)
+	}
+}
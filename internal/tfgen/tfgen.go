@@ -0,0 +1,379 @@
+// Package tfgen writes Terraform configuration for the resources of this
+// provider. It is shared by the commands that generate configuration, e.g.
+// from a `render.yaml` blueprint, so their output follows the same schema.
+package tfgen
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Service holds the arguments of a render_service resource. Empty values are left out.
+type Service struct {
+	Name          string
+	Type          string
+	Repo          string
+	Branch        string
+	Owner         string
+	AutoDeploy    *bool
+	RootDirectory string
+
+	// Env is the environment of web, private and background worker services, e.g. `node` or `docker`.
+	Env              string
+	Region           string
+	Plan             string
+	HealthCheckPath  string
+	BuildCommand     string
+	StartCommand     string
+	PreDeployCommand string
+	DockerCommand    string
+	PublishPath      string
+
+	MaxShutdownDelaySeconds int
+	Disk                    *Disk
+	BuildFilter             *BuildFilter
+	Previews                *Previews
+}
+
+type Disk struct {
+	Name      string
+	MountPath string
+	SizeGB    int
+}
+
+type BuildFilter struct {
+	Paths        []string
+	IgnoredPaths []string
+}
+
+type Previews struct {
+	Generation      string
+	ExpireAfterDays int
+	Plan            string
+}
+
+// Environment holds the variables of a render_service_environment resource.
+type Environment struct {
+	Variables []Variable
+	// Sensitive maps variable names to expressions, e.g. `var.database_url`.
+	Sensitive map[string]string
+}
+
+// Variable is an item of `variables`, either a plain value, a reference or generated by Render.
+type Variable struct {
+	Key   string
+	Value string
+	// Expression is used instead of Value when set, e.g. `var.api_token`.
+	Expression string
+	Generated  bool
+}
+
+type Header struct {
+	Path  string
+	Name  string
+	Value string
+}
+
+// File is a Terraform configuration file being generated.
+type File struct {
+	file   *hclwrite.File
+	labels map[string]bool
+}
+
+func NewFile() *File {
+	return &File{file: hclwrite.NewEmptyFile(), labels: map[string]bool{}}
+}
+
+// Bytes returns the formatted configuration.
+func (f *File) Bytes() []byte {
+	return hclwrite.Format(f.file.Bytes())
+}
+
+// Label returns a resource name for name, e.g. `my_api` for "My API", that is unique among
+// the labels of the file.
+func (f *File) Label(name string) string {
+	label := Label(name)
+
+	for i := 2; f.labels[label]; i++ {
+		label = Label(name) + "_" + strconv.Itoa(i)
+	}
+
+	f.labels[label] = true
+
+	return label
+}
+
+// Variable declares an input variable, sensitive when its value is a secret.
+func (f *File) Variable(name string, description string, sensitive bool) {
+	body := f.block("variable", name)
+
+	body.SetAttributeTraversal("type", hcl.Traversal{hcl.TraverseRoot{Name: "string"}})
+
+	if description != "" {
+		body.SetAttributeValue("description", cty.StringVal(description))
+	}
+
+	if sensitive {
+		body.SetAttributeValue("sensitive", cty.True)
+	}
+}
+
+// Service adds a render_service resource.
+func (f *File) Service(label string, s Service) {
+	body := f.block("resource", "render_service", label)
+
+	body.SetAttributeValue("name", cty.StringVal(s.Name))
+	body.SetAttributeValue("type", cty.StringVal(s.Type))
+	body.SetAttributeValue("repo", cty.StringVal(s.Repo))
+	setString(body, "branch", s.Branch)
+	setString(body, "owner", s.Owner)
+
+	if s.AutoDeploy != nil {
+		body.SetAttributeValue("auto_deploy", cty.BoolVal(*s.AutoDeploy))
+	}
+
+	setString(body, "root_directory", s.RootDirectory)
+
+	var details object
+
+	switch s.Type {
+	case "static_site":
+		details.string("build_command", s.BuildCommand)
+		details.string("publish_path", s.PublishPath)
+	default:
+		details.string("env", s.Env)
+		details.string("region", s.Region)
+		details.string("plan", s.Plan)
+
+		if s.Type == "web_service" {
+			details.string("health_check_path", s.HealthCheckPath)
+		}
+
+		details.string("pre_deploy_command", s.PreDeployCommand)
+
+		if s.Type == "web_service" {
+			details.string("docker_command", s.DockerCommand)
+			details.int("max_shutdown_delay_seconds", s.MaxShutdownDelaySeconds)
+		}
+
+		if s.Type != "private_service" && (s.BuildCommand != "" || s.StartCommand != "") {
+			var native object
+			native.string("build_command", s.BuildCommand)
+			native.string("start_command", s.StartCommand)
+			details.object("native", native)
+		}
+
+		if s.Type != "web_service" && s.Disk != nil {
+			var disk object
+			disk.string("name", s.Disk.Name)
+			disk.string("mount_path", s.Disk.MountPath)
+			disk.int("size_gb", s.Disk.SizeGB)
+			details.object("disk", disk)
+		}
+	}
+
+	body.SetAttributeRaw(s.Type+"_details", details.tokens())
+
+	if s.BuildFilter != nil {
+		filter := body.AppendNewBlock("build_filter", nil).Body()
+		setStrings(filter, "paths", s.BuildFilter.Paths)
+		setStrings(filter, "ignored_paths", s.BuildFilter.IgnoredPaths)
+	}
+
+	if s.Previews != nil {
+		previews := body.AppendNewBlock("previews", nil).Body()
+		setString(previews, "generation", s.Previews.Generation)
+
+		if s.Previews.ExpireAfterDays > 0 {
+			previews.SetAttributeValue("expire_after_days", cty.NumberIntVal(int64(s.Previews.ExpireAfterDays)))
+		}
+
+		setString(previews, "plan", s.Previews.Plan)
+	}
+}
+
+// Environment adds a render_service_environment resource for the service of serviceLabel.
+func (f *File) Environment(label string, serviceLabel string, e Environment) {
+	body := f.block("resource", "render_service_environment", label)
+
+	body.SetAttributeTraversal("service", reference("render_service", serviceLabel, "id"))
+
+	var variables object
+
+	for _, variable := range e.Variables {
+		var item object
+
+		switch {
+		case variable.Generated:
+			item.attribute("generated", hclwrite.TokensForValue(cty.True))
+		case variable.Expression != "":
+			item.attribute("value", hclwrite.TokensForTraversal(traversal(variable.Expression)))
+		default:
+			item.attribute("value", hclwrite.TokensForValue(cty.StringVal(variable.Value)))
+		}
+
+		variables.object(variable.Key, item)
+	}
+
+	body.SetAttributeRaw("variables", variables.tokens())
+
+	if len(e.Sensitive) > 0 {
+		var sensitive object
+
+		for _, key := range sortedKeys(e.Sensitive) {
+			sensitive.attribute(key, hclwrite.TokensForTraversal(traversal(e.Sensitive[key])))
+		}
+
+		body.SetAttributeRaw("sensitive_variables", sensitive.tokens())
+	}
+}
+
+// CustomDomain adds a render_service_custom_domain resource for the service of serviceLabel.
+func (f *File) CustomDomain(label string, serviceLabel string, domainName string) {
+	body := f.block("resource", "render_service_custom_domain", label)
+
+	body.SetAttributeTraversal("service_id", reference("render_service", serviceLabel, "id"))
+	body.SetAttributeValue("domain_name", cty.StringVal(domainName))
+}
+
+// Headers adds a render_service_headers resource for the static site of serviceLabel.
+func (f *File) Headers(label string, serviceLabel string, headers []Header) {
+	body := f.block("resource", "render_service_headers", label)
+
+	body.SetAttributeTraversal("service_id", reference("render_service", serviceLabel, "id"))
+
+	items := make([]hclwrite.Tokens, 0, len(headers))
+
+	for _, header := range headers {
+		var item object
+		item.attribute("path", hclwrite.TokensForValue(cty.StringVal(header.Path)))
+		item.attribute("name", hclwrite.TokensForValue(cty.StringVal(header.Name)))
+		item.attribute("value", hclwrite.TokensForValue(cty.StringVal(header.Value)))
+		items = append(items, item.tokens())
+	}
+
+	body.SetAttributeRaw("headers", hclwrite.TokensForTuple(items))
+}
+
+// Import adds a Terraform 1.5 `import` block for the resource at address, e.g. `render_service.api`.
+func (f *File) Import(address string, id string) {
+	body := f.block("import")
+
+	body.SetAttributeTraversal("to", traversal(address))
+	body.SetAttributeValue("id", cty.StringVal(id))
+}
+
+func (f *File) block(typeName string, labels ...string) *hclwrite.Body {
+	root := f.file.Body()
+
+	if len(root.Blocks()) > 0 {
+		root.AppendNewline()
+	}
+
+	return root.AppendNewBlock(typeName, labels).Body()
+}
+
+var invalidLabelCharacters = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// Label converts a name to a resource name, e.g. `my_api` for "My API".
+func Label(name string) string {
+	label := strings.Trim(invalidLabelCharacters.ReplaceAllString(strings.ToLower(name), "_"), "_")
+
+	if label == "" || (label[0] >= '0' && label[0] <= '9') {
+		label = "r_" + label
+	}
+
+	return label
+}
+
+// object collects the attributes of an object expression, in order.
+type object []hclwrite.ObjectAttrTokens
+
+func (o *object) attribute(name string, value hclwrite.Tokens) {
+	key := hclwrite.TokensForIdentifier(name)
+
+	if !hclsyntax.ValidIdentifier(name) {
+		key = hclwrite.TokensForValue(cty.StringVal(name))
+	}
+
+	*o = append(*o, hclwrite.ObjectAttrTokens{Name: key, Value: value})
+}
+
+func (o *object) string(name string, value string) {
+	if value != "" {
+		o.attribute(name, hclwrite.TokensForValue(cty.StringVal(value)))
+	}
+}
+
+func (o *object) int(name string, value int) {
+	if value != 0 {
+		o.attribute(name, hclwrite.TokensForValue(cty.NumberIntVal(int64(value))))
+	}
+}
+
+func (o *object) object(name string, value object) {
+	o.attribute(name, value.tokens())
+}
+
+func (o object) tokens() hclwrite.Tokens {
+	return hclwrite.TokensForObject(o)
+}
+
+func setString(body *hclwrite.Body, name string, value string) {
+	if value != "" {
+		body.SetAttributeValue(name, cty.StringVal(value))
+	}
+}
+
+func setStrings(body *hclwrite.Body, name string, values []string) {
+	if len(values) == 0 {
+		return
+	}
+
+	items := make([]cty.Value, len(values))
+
+	for i, value := range values {
+		items[i] = cty.StringVal(value)
+	}
+
+	body.SetAttributeValue(name, cty.ListVal(items))
+}
+
+func reference(resourceType string, label string, attribute string) hcl.Traversal {
+	return hcl.Traversal{
+		hcl.TraverseRoot{Name: resourceType},
+		hcl.TraverseAttr{Name: label},
+		hcl.TraverseAttr{Name: attribute},
+	}
+}
+
+// traversal parses a dotted address like `var.database_url`.
+func traversal(address string) hcl.Traversal {
+	parts := strings.Split(address, ".")
+	result := hcl.Traversal{hcl.TraverseRoot{Name: parts[0]}}
+
+	for _, part := range parts[1:] {
+		result = append(result, hcl.TraverseAttr{Name: part})
+	}
+
+	return result
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,127 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+type BlueprintStatus string
+
+const (
+	BlueprintCreated BlueprintStatus = "created"
+	BlueprintPaused  BlueprintStatus = "paused"
+	BlueprintInSync  BlueprintStatus = "in_sync"
+	BlueprintSyncing BlueprintStatus = "syncing"
+	BlueprintError   BlueprintStatus = "error"
+)
+
+// Blueprint is a repository linked to Render through its `render.yaml`.
+type Blueprint struct {
+	Id        string              `json:"id"`
+	Name      string              `json:"name"`
+	OwnerId   string              `json:"ownerId,omitempty"`
+	Status    BlueprintStatus     `json:"status"`
+	AutoSync  bool                `json:"autoSync"`
+	Repo      string              `json:"repo"`
+	Branch    string              `json:"branch"`
+	Path      string              `json:"path,omitempty"`
+	LastSync  *time.Time          `json:"lastSync,omitempty"`
+	Resources []BlueprintResource `json:"resources,omitempty"`
+}
+
+// BlueprintResource is a service, database or environment group managed by a blueprint.
+type BlueprintResource struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+type BlueprintPATCH struct {
+	Name     *string `json:"name,omitempty"`
+	AutoSync *bool   `json:"autoSync,omitempty"`
+	Path     *string `json:"path,omitempty"`
+}
+
+type BlueprintsResponse struct {
+	Response
+	JSON200 *[]struct {
+		Blueprint *Blueprint `json:"blueprint,omitempty"`
+		Cursor    *string    `json:"cursor,omitempty"`
+	}
+}
+
+type BlueprintResponse struct {
+	Response
+	JSON200 *Blueprint
+}
+
+// ListBlueprintsWithResponse lists the blueprints of an owner, a page at a time.
+func (c *Client) ListBlueprintsWithResponse(ctx context.Context, ownerId string, cursor *string, limit int) (*BlueprintsResponse, error) {
+	query := url.Values{}
+	query.Set("limit", fmt.Sprint(limit))
+
+	if ownerId != "" {
+		query.Set("ownerId", ownerId)
+	}
+
+	if cursor != nil {
+		query.Set("cursor", *cursor)
+	}
+
+	response, err := c.do(ctx, http.MethodGet, "/blueprints", query, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BlueprintsResponse{Response: *response}
+
+	if err := decode(response, http.StatusOK, &result.JSON200); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetBlueprintWithResponse reads a blueprint including the resources it manages.
+func (c *Client) GetBlueprintWithResponse(ctx context.Context, blueprintId string) (*BlueprintResponse, error) {
+	response, err := c.do(ctx, http.MethodGet, fmt.Sprintf("/blueprints/%s", blueprintId), nil, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BlueprintResponse{Response: *response}
+
+	if err := decode(response, http.StatusOK, &result.JSON200); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// UpdateBlueprintWithResponse updates the settings of a blueprint.
+func (c *Client) UpdateBlueprintWithResponse(ctx context.Context, blueprintId string, body BlueprintPATCH) (*BlueprintResponse, error) {
+	response, err := c.do(ctx, http.MethodPatch, fmt.Sprintf("/blueprints/%s", blueprintId), nil, body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BlueprintResponse{Response: *response}
+
+	if err := decode(response, http.StatusOK, &result.JSON200); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// DisconnectBlueprintWithResponse unlinks a blueprint from its repository.
+// The resources it created are kept.
+func (c *Client) DisconnectBlueprintWithResponse(ctx context.Context, blueprintId string) (*Response, error) {
+	return c.do(ctx, http.MethodDelete, fmt.Sprintf("/blueprints/%s", blueprintId), nil, nil)
+}
@@ -0,0 +1,132 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/jackall3n/render-go"
+)
+
+// Client covers the Render API endpoints that are not (yet) part of render-go.
+// It shares the server and request editors with the generated client so both
+// authenticate the same way.
+type Client struct {
+	Server         string
+	Client         render.HttpRequestDoer
+	RequestEditors []render.RequestEditorFn
+}
+
+func NewClient(server string, editors ...render.RequestEditorFn) *Client {
+	if !strings.HasSuffix(server, "/") {
+		server += "/"
+	}
+
+	return &Client{
+		Server:         server,
+		Client:         &http.Client{},
+		RequestEditors: editors,
+	}
+}
+
+// Response is the raw result of an API call, shaped like the render-go responses.
+type Response struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+func (r Response) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+
+	return http.StatusText(0)
+}
+
+func (r Response) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+
+	return 0
+}
+
+func (c *Client) do(ctx context.Context, method string, path string, query url.Values, body interface{}) (*Response, error) {
+	serverURL, err := url.Parse(c.Server)
+
+	if err != nil {
+		return nil, err
+	}
+
+	queryURL, err := serverURL.Parse("." + path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if query != nil {
+		queryURL.RawQuery = query.Encode()
+	}
+
+	var reader io.Reader
+
+	if body != nil {
+		buf, err := json.Marshal(body)
+
+		if err != nil {
+			return nil, err
+		}
+
+		reader = bytes.NewReader(buf)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, queryURL.String(), reader)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if body != nil {
+		req.Header.Add("Content-Type", "application/json")
+	}
+
+	for _, editor := range c.RequestEditors {
+		if err := editor(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+
+	rsp, err := c.Client.Do(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = rsp.Body.Close() }()
+
+	bodyBytes, err := io.ReadAll(rsp.Body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Response{Body: bodyBytes, HTTPResponse: rsp}, nil
+}
+
+// decode unmarshals the response body into out when the status matches.
+func decode(response *Response, status int, out interface{}) error {
+	if response.StatusCode() != status {
+		return nil
+	}
+
+	if err := json.Unmarshal(response.Body, out); err != nil {
+		return fmt.Errorf("failed to decode response: %s", err.Error())
+	}
+
+	return nil
+}
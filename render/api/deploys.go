@@ -0,0 +1,46 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/jackall3n/render-go"
+)
+
+// PreDeployInProgress is the status of a deploy running its pre-deploy command, which render-go doesn't define yet.
+const PreDeployInProgress render.DeployStatus = "pre_deploy_in_progress"
+
+// DeployInProgress reports whether a deploy is still building or starting, i.e. will change status again.
+func DeployInProgress(status render.DeployStatus) bool {
+	switch status {
+	case render.Created, render.BuildInProgress, render.UpdateInProgress, PreDeployInProgress:
+		return true
+	default:
+		return false
+	}
+}
+
+type RollbackDeployResponse struct {
+	Response
+	JSON201 *render.Deploy
+}
+
+// RollbackDeployWithResponse starts a new deploy of a service from an earlier deploy, e.g. the last one that worked.
+func (c *Client) RollbackDeployWithResponse(ctx context.Context, serviceId string, deployId string) (*RollbackDeployResponse, error) {
+	body := map[string]string{"deployId": deployId}
+
+	response, err := c.do(ctx, http.MethodPost, fmt.Sprintf("/services/%s/rollback", serviceId), nil, body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := &RollbackDeployResponse{Response: *response}
+
+	if err := decode(response, http.StatusCreated, &result.JSON201); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
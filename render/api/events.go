@@ -0,0 +1,62 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+type EventType string
+
+const (
+	ServerAvailable EventType = "server_available"
+	ServerFailed    EventType = "server_failed"
+)
+
+// Event is something that happened to a service, e.g. an instance becoming healthy after a restart.
+type Event struct {
+	Id        string          `json:"id"`
+	Timestamp time.Time       `json:"timestamp"`
+	ServiceId string          `json:"serviceId"`
+	Type      EventType       `json:"type"`
+	Details   json.RawMessage `json:"details,omitempty"`
+}
+
+type EventsResponse struct {
+	Response
+	JSON200 *[]struct {
+		Event  *Event  `json:"event,omitempty"`
+		Cursor *string `json:"cursor,omitempty"`
+	}
+}
+
+// ListServiceEventsWithResponse lists the events of a service of the given types, newest first, a page at a time.
+func (c *Client) ListServiceEventsWithResponse(ctx context.Context, serviceId string, eventTypes []EventType, cursor *string, limit int) (*EventsResponse, error) {
+	query := url.Values{}
+	query.Set("limit", fmt.Sprint(limit))
+
+	for _, eventType := range eventTypes {
+		query.Add("type", string(eventType))
+	}
+
+	if cursor != nil {
+		query.Set("cursor", *cursor)
+	}
+
+	response, err := c.do(ctx, http.MethodGet, fmt.Sprintf("/services/%s/events", serviceId), query, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := &EventsResponse{Response: *response}
+
+	if err := decode(response, http.StatusOK, &result.JSON200); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,31 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/jackall3n/render-go"
+)
+
+type UpdateHeadersResponse struct {
+	Response
+	JSON200 *[]render.Header
+}
+
+// UpdateHeadersWithResponse replaces every header rule of a static site.
+func (c *Client) UpdateHeadersWithResponse(ctx context.Context, serviceId string, headers []render.Header) (*UpdateHeadersResponse, error) {
+	response, err := c.do(ctx, http.MethodPut, fmt.Sprintf("/services/%s/headers", serviceId), nil, headers)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := &UpdateHeadersResponse{Response: *response}
+
+	if err := decode(response, http.StatusOK, &result.JSON200); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,238 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/jackall3n/render-go"
+)
+
+type ProtectedStatus string
+
+const (
+	Protected   ProtectedStatus = "protected"
+	Unprotected ProtectedStatus = "unprotected"
+)
+
+// Project groups services into environments, e.g. production and staging.
+type Project struct {
+	Id             string        `json:"id"`
+	Name           string        `json:"name"`
+	Owner          *render.Owner `json:"owner,omitempty"`
+	EnvironmentIds []string      `json:"environmentIds"`
+}
+
+type ProjectPOST struct {
+	Name         string             `json:"name"`
+	OwnerId      string             `json:"ownerId"`
+	Environments []EnvironmentInput `json:"environments"`
+}
+
+type ProjectPATCH struct {
+	Name *string `json:"name,omitempty"`
+}
+
+// Environment is an environment of a project. Protected environments can only be changed
+// by admins, and network isolated ones block private network traffic from other environments.
+type Environment struct {
+	Id                      string          `json:"id"`
+	Name                    string          `json:"name"`
+	ProjectId               string          `json:"projectId"`
+	ProtectedStatus         ProtectedStatus `json:"protectedStatus"`
+	NetworkIsolationEnabled bool            `json:"networkIsolationEnabled"`
+	ServiceIds              []string        `json:"serviceIds"`
+}
+
+// EnvironmentInput is an environment created along with its project.
+type EnvironmentInput struct {
+	Name                    string          `json:"name"`
+	ProtectedStatus         ProtectedStatus `json:"protectedStatus,omitempty"`
+	NetworkIsolationEnabled *bool           `json:"networkIsolationEnabled,omitempty"`
+}
+
+type EnvironmentPOST struct {
+	EnvironmentInput
+	ProjectId string `json:"projectId"`
+}
+
+type EnvironmentPATCH struct {
+	Name                    *string          `json:"name,omitempty"`
+	ProtectedStatus         *ProtectedStatus `json:"protectedStatus,omitempty"`
+	NetworkIsolationEnabled *bool            `json:"networkIsolationEnabled,omitempty"`
+}
+
+type ProjectResponse struct {
+	Response
+	JSON200 *Project
+}
+
+type CreateProjectResponse struct {
+	Response
+	JSON201 *Project
+}
+
+type EnvironmentsResponse struct {
+	Response
+	JSON200 *[]struct {
+		Environment *Environment `json:"environment,omitempty"`
+		Cursor      *string      `json:"cursor,omitempty"`
+	}
+}
+
+type EnvironmentResponse struct {
+	Response
+	JSON200 *Environment
+}
+
+type CreateEnvironmentResponse struct {
+	Response
+	JSON201 *Environment
+}
+
+// CreateProjectWithResponse creates a project. Render requires at least one environment.
+func (c *Client) CreateProjectWithResponse(ctx context.Context, body ProjectPOST) (*CreateProjectResponse, error) {
+	response, err := c.do(ctx, http.MethodPost, "/projects", nil, body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := &CreateProjectResponse{Response: *response}
+
+	if err := decode(response, http.StatusCreated, &result.JSON201); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (c *Client) GetProjectWithResponse(ctx context.Context, projectId string) (*ProjectResponse, error) {
+	response, err := c.do(ctx, http.MethodGet, fmt.Sprintf("/projects/%s", projectId), nil, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ProjectResponse{Response: *response}
+
+	if err := decode(response, http.StatusOK, &result.JSON200); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (c *Client) UpdateProjectWithResponse(ctx context.Context, projectId string, body ProjectPATCH) (*ProjectResponse, error) {
+	response, err := c.do(ctx, http.MethodPatch, fmt.Sprintf("/projects/%s", projectId), nil, body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ProjectResponse{Response: *response}
+
+	if err := decode(response, http.StatusOK, &result.JSON200); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// DeleteProjectWithResponse deletes a project and its environments.
+func (c *Client) DeleteProjectWithResponse(ctx context.Context, projectId string) (*Response, error) {
+	return c.do(ctx, http.MethodDelete, fmt.Sprintf("/projects/%s", projectId), nil, nil)
+}
+
+// ListEnvironmentsWithResponse lists the environments of a project, a page at a time.
+func (c *Client) ListEnvironmentsWithResponse(ctx context.Context, projectId string, cursor *string, limit int) (*EnvironmentsResponse, error) {
+	query := url.Values{}
+	query.Set("projectId", projectId)
+	query.Set("limit", fmt.Sprint(limit))
+
+	if cursor != nil {
+		query.Set("cursor", *cursor)
+	}
+
+	response, err := c.do(ctx, http.MethodGet, "/environments", query, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := &EnvironmentsResponse{Response: *response}
+
+	if err := decode(response, http.StatusOK, &result.JSON200); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (c *Client) CreateEnvironmentWithResponse(ctx context.Context, body EnvironmentPOST) (*CreateEnvironmentResponse, error) {
+	response, err := c.do(ctx, http.MethodPost, "/environments", nil, body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := &CreateEnvironmentResponse{Response: *response}
+
+	if err := decode(response, http.StatusCreated, &result.JSON201); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (c *Client) GetEnvironmentWithResponse(ctx context.Context, environmentId string) (*EnvironmentResponse, error) {
+	response, err := c.do(ctx, http.MethodGet, fmt.Sprintf("/environments/%s", environmentId), nil, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := &EnvironmentResponse{Response: *response}
+
+	if err := decode(response, http.StatusOK, &result.JSON200); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (c *Client) UpdateEnvironmentWithResponse(ctx context.Context, environmentId string, body EnvironmentPATCH) (*EnvironmentResponse, error) {
+	response, err := c.do(ctx, http.MethodPatch, fmt.Sprintf("/environments/%s", environmentId), nil, body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := &EnvironmentResponse{Response: *response}
+
+	if err := decode(response, http.StatusOK, &result.JSON200); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// DeleteEnvironmentWithResponse deletes an environment. Its services are kept, outside of any environment.
+func (c *Client) DeleteEnvironmentWithResponse(ctx context.Context, environmentId string) (*Response, error) {
+	return c.do(ctx, http.MethodDelete, fmt.Sprintf("/environments/%s", environmentId), nil, nil)
+}
+
+// AddResourcesToEnvironmentWithResponse moves services into an environment.
+func (c *Client) AddResourcesToEnvironmentWithResponse(ctx context.Context, environmentId string, resourceIds []string) (*Response, error) {
+	body := map[string][]string{"resourceIds": resourceIds}
+
+	return c.do(ctx, http.MethodPost, fmt.Sprintf("/environments/%s/resources", environmentId), nil, body)
+}
+
+// RemoveResourcesFromEnvironmentWithResponse moves services out of an environment.
+func (c *Client) RemoveResourcesFromEnvironmentWithResponse(ctx context.Context, environmentId string, resourceIds []string) (*Response, error) {
+	query := url.Values{"resourceIds": resourceIds}
+
+	return c.do(ctx, http.MethodDelete, fmt.Sprintf("/environments/%s/resources", environmentId), query, nil)
+}
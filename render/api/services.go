@@ -0,0 +1,158 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/jackall3n/render-go"
+)
+
+// Service is a render.Service including the fields render-go doesn't decode yet.
+type Service struct {
+	render.Service
+	RootDir       *string      `json:"rootDir,omitempty"`
+	BuildFilter   *BuildFilter `json:"buildFilter,omitempty"`
+	EnvironmentId *string      `json:"environmentId,omitempty"`
+}
+
+// BuildFilter limits the changed files that trigger an auto deploy.
+type BuildFilter struct {
+	Paths        []string `json:"paths"`
+	IgnoredPaths []string `json:"ignoredPaths"`
+}
+
+// ServicePOST is a render.ServicePOST including the fields render-go doesn't encode yet.
+type ServicePOST struct {
+	render.ServicePOST
+	RootDir     *string      `json:"rootDir,omitempty"`
+	BuildFilter *BuildFilter `json:"buildFilter,omitempty"`
+}
+
+// ServicePATCH is a render.ServicePATCH including the fields render-go doesn't encode yet.
+type ServicePATCH struct {
+	render.ServicePATCH
+	RootDir     *string      `json:"rootDir,omitempty"`
+	BuildFilter *BuildFilter `json:"buildFilter,omitempty"`
+}
+
+// ServiceDetails holds the fields of the serviceDetails of a service render-go doesn't decode yet.
+type ServiceDetails struct {
+	MaxShutdownDelaySeconds *int      `json:"maxShutdownDelaySeconds,omitempty"`
+	Previews                *Previews `json:"previews,omitempty"`
+}
+
+// Previews configures the preview instances of pull requests.
+type Previews struct {
+	Generation      *string `json:"generation,omitempty"`
+	ExpireAfterDays *int    `json:"expireAfterDays,omitempty"`
+	Plan            *string `json:"plan,omitempty"`
+}
+
+// EnvSpecificDetails holds the native and docker environment details of a service,
+// including the fields render-go doesn't decode yet.
+type EnvSpecificDetails struct {
+	BuildCommand     *string `json:"buildCommand,omitempty"`
+	StartCommand     *string `json:"startCommand,omitempty"`
+	PreDeployCommand *string `json:"preDeployCommand,omitempty"`
+	DockerCommand    *string `json:"dockerCommand,omitempty"`
+}
+
+// DecodeServiceDetails decodes the render.Service_ServiceDetails union.
+func DecodeServiceDetails(union json.Marshaler) (ServiceDetails, error) {
+	var details ServiceDetails
+
+	err := decodeUnion(union, &details)
+
+	return details, err
+}
+
+// DecodeEnvSpecificDetails decodes one of the envSpecificDetails unions of render-go,
+// e.g. render.WebServiceDetails_EnvSpecificDetails.
+func DecodeEnvSpecificDetails(union json.Marshaler) (EnvSpecificDetails, error) {
+	var details EnvSpecificDetails
+
+	err := decodeUnion(union, &details)
+
+	return details, err
+}
+
+func decodeUnion(union json.Marshaler, out interface{}) error {
+	raw, err := union.MarshalJSON()
+
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(raw, out)
+}
+
+type CreateServiceResponse struct {
+	Response
+	JSON201 *struct {
+		DeployId *string  `json:"deployId,omitempty"`
+		Service  *Service `json:"service,omitempty"`
+	}
+}
+
+type ServiceResponse struct {
+	Response
+	JSON200 *Service
+}
+
+// CreateServiceWithResponse creates a service, like render-go, without dropping the fields of ServicePOST.
+func (c *Client) CreateServiceWithResponse(ctx context.Context, body ServicePOST) (*CreateServiceResponse, error) {
+	response, err := c.do(ctx, http.MethodPost, "/services", nil, body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := &CreateServiceResponse{Response: *response}
+
+	if err := decode(response, http.StatusCreated, &result.JSON201); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetServiceWithResponse reads a service including the fields of Service.
+func (c *Client) GetServiceWithResponse(ctx context.Context, serviceId string) (*ServiceResponse, error) {
+	response, err := c.do(ctx, http.MethodGet, fmt.Sprintf("/services/%s", serviceId), nil, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ServiceResponse{Response: *response}
+
+	if err := decode(response, http.StatusOK, &result.JSON200); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// UpdateServiceWithResponse updates a service, like render-go, without dropping the fields of ServicePATCH.
+func (c *Client) UpdateServiceWithResponse(ctx context.Context, serviceId string, body ServicePATCH) (*ServiceResponse, error) {
+	response, err := c.do(ctx, http.MethodPatch, fmt.Sprintf("/services/%s", serviceId), nil, body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ServiceResponse{Response: *response}
+
+	if err := decode(response, http.StatusOK, &result.JSON200); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// RestartServiceWithResponse restarts the instances of a service without building or deploying it again.
+func (c *Client) RestartServiceWithResponse(ctx context.Context, serviceId string) (*Response, error) {
+	return c.do(ctx, http.MethodPost, fmt.Sprintf("/services/%s/restart", serviceId), nil, nil)
+}
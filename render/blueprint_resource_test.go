@@ -0,0 +1,233 @@
+package render
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/jackall3n/render-go"
+	"github.com/jackall3n/terraform-provider-render/internal/fakerender"
+	"github.com/jackall3n/terraform-provider-render/render/api"
+)
+
+const testAccBlueprintRepo = "https://github.com/render-examples/blueprint"
+
+func testAccBlueprintConfig(attributes string) string {
+	return fmt.Sprintf(`
+resource "render_blueprint" "app" {
+  repo = %q
+  %s
+}
+`, testAccBlueprintRepo, attributes)
+}
+
+// testAccCheckBlueprintDisconnected fails when a blueprint is still linked after destroy.
+func testAccCheckBlueprintDisconnected(server *fakerender.Server, id string) func(*terraform.State) error {
+	return func(_ *terraform.State) error {
+		if _, ok := server.Blueprint(id); ok {
+			return fmt.Errorf("expected blueprint %s to be disconnected", id)
+		}
+
+		return nil
+	}
+}
+
+func TestAccBlueprintResource(t *testing.T) {
+	server := newTestAccServer(t)
+
+	id := server.AddBlueprint(api.Blueprint{
+		Name:    "app",
+		OwnerId: testAccOwnerID,
+		Repo:    testAccBlueprintRepo,
+		Resources: []api.BlueprintResource{
+			{Id: "srv-00000001", Name: "web", Type: "web_service"},
+			{Id: "srv-00000002", Name: "worker", Type: "background_worker"},
+			{Id: "dpg-00000001", Name: "db", Type: "postgres"},
+			{Id: "evg-00000001", Name: "shared", Type: "environment_group"},
+		},
+	})
+
+	server.AddBlueprint(api.Blueprint{Name: "other", OwnerId: testAccOwnerID, Repo: "https://github.com/render-examples/other"})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckBlueprintDisconnected(server, id),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccConfig(server, testAccBlueprintConfig(`branch = "develop"`)),
+				ExpectError: regexp.MustCompile(`The Render API\s+can.t create blueprints`),
+			},
+			{
+				Config: testAccConfig(server, testAccBlueprintConfig(`auto_sync = true`)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("render_blueprint.app", "id", id),
+					resource.TestCheckResourceAttr("render_blueprint.app", "owner", testAccOwnerID),
+					resource.TestCheckResourceAttr("render_blueprint.app", "name", "app"),
+					resource.TestCheckResourceAttr("render_blueprint.app", "branch", "main"),
+					resource.TestCheckResourceAttr("render_blueprint.app", "path", "render.yaml"),
+					resource.TestCheckResourceAttr("render_blueprint.app", "auto_sync", "true"),
+					resource.TestCheckResourceAttr("render_blueprint.app", "status", "in_sync"),
+					resource.TestCheckResourceAttrSet("render_blueprint.app", "last_sync"),
+					resource.TestCheckResourceAttr("render_blueprint.app", "service_ids.#", "2"),
+					resource.TestCheckResourceAttr("render_blueprint.app", "service_ids.0", "srv-00000001"),
+					resource.TestCheckResourceAttr("render_blueprint.app", "database_ids.#", "1"),
+					resource.TestCheckResourceAttr("render_blueprint.app", "database_ids.0", "dpg-00000001"),
+					resource.TestCheckResourceAttr("render_blueprint.app", "resources.#", "4"),
+					resource.TestCheckResourceAttr("render_blueprint.app", "resources.3.type", "environment_group"),
+				),
+			},
+			{
+				Config: testAccConfig(server, testAccBlueprintConfig(`
+  name      = "app-production"
+  path      = "deploy/render.yaml"
+  auto_sync = false
+`)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("render_blueprint.app", "id", id),
+					resource.TestCheckResourceAttr("render_blueprint.app", "auto_sync", "false"),
+					func(_ *terraform.State) error {
+						blueprint, _ := server.Blueprint(id)
+
+						if blueprint.Name != "app-production" || blueprint.Path != "deploy/render.yaml" || blueprint.AutoSync {
+							return fmt.Errorf("expected the blueprint to be updated, got %+v", blueprint)
+						}
+
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:            "render_blueprint.app",
+				ImportState:             true,
+				ImportStateId:           id,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+		},
+	})
+}
+
+func TestAccBlueprintResourceOwnerChange(t *testing.T) {
+	server := newTestAccServer(t)
+
+	teamId := "tea-00000001"
+	teamName := "Acme"
+	teamType := render.OwnerTypeTeam
+
+	server.AddOwner(render.Owner{Id: teamId, Name: &teamName, Type: &teamType})
+
+	userBlueprint := server.AddBlueprint(api.Blueprint{Name: "app", OwnerId: testAccOwnerID, Repo: testAccBlueprintRepo})
+	teamBlueprint := server.AddBlueprint(api.Blueprint{Name: "app", OwnerId: teamId, Repo: testAccBlueprintRepo})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckBlueprintDisconnected(server, teamBlueprint),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(server, testAccBlueprintConfig("")),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("render_blueprint.app", "id", userBlueprint),
+					resource.TestCheckResourceAttr("render_blueprint.app", "owner", testAccOwnerID),
+				),
+			},
+			// The owner of a blueprint can't be changed, another owner's blueprint is taken over instead
+			{
+				Config: testAccConfig(server, testAccBlueprintConfig(fmt.Sprintf("owner = %q", teamId))),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("render_blueprint.app", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("render_blueprint.app", "id", teamBlueprint),
+					resource.TestCheckResourceAttr("render_blueprint.app", "owner", teamId),
+					testAccCheckBlueprintDisconnected(server, userBlueprint),
+				),
+			},
+		},
+	})
+}
+
+func TestAccBlueprintResourceRepoSpelling(t *testing.T) {
+	server := newTestAccServer(t)
+
+	id := server.AddBlueprint(api.Blueprint{Name: "app", OwnerId: testAccOwnerID, Repo: testAccBlueprintRepo})
+
+	// The blueprint is matched however its repository is spelled, state keeps the configured spelling
+	repo := "https://github.com/Render-Examples/Blueprint.git"
+
+	config := testAccConfig(server, fmt.Sprintf(`
+resource "render_blueprint" "app" {
+  repo = %q
+}
+`, repo))
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckBlueprintDisconnected(server, id),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("render_blueprint.app", "id", id),
+					resource.TestCheckResourceAttr("render_blueprint.app", "repo", repo),
+				),
+			},
+			{
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestAccBlueprintDataSource(t *testing.T) {
+	server := newTestAccServer(t)
+
+	id := server.AddBlueprint(api.Blueprint{
+		Name:      "app",
+		OwnerId:   testAccOwnerID,
+		Repo:      testAccBlueprintRepo,
+		Resources: []api.BlueprintResource{{Id: "srv-00000001", Name: "web", Type: "web_service"}},
+	})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(server, `
+data "render_blueprint" "unknown" {
+  name = "missing"
+}
+`),
+				ExpectError: regexp.MustCompile(`no blueprint was found for name \[missing\]`),
+			},
+			{
+				Config: testAccConfig(server, fmt.Sprintf(`
+data "render_blueprint" "by_name" {
+  name = "app"
+}
+
+data "render_blueprint" "by_id" {
+  id = %q
+}
+`, id)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.render_blueprint.by_name", "id", id),
+					resource.TestCheckResourceAttr("data.render_blueprint.by_name", "repo", testAccBlueprintRepo),
+					resource.TestCheckResourceAttr("data.render_blueprint.by_name", "service_ids.0", "srv-00000001"),
+					resource.TestCheckResourceAttr("data.render_blueprint.by_id", "name", "app"),
+					resource.TestCheckResourceAttr("data.render_blueprint.by_id", "owner", testAccOwnerID),
+					resource.TestCheckResourceAttr("data.render_blueprint.by_id", "database_ids.#", "0"),
+				),
+			},
+		},
+	})
+}
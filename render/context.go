@@ -3,55 +3,120 @@ package render
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"strings"
+
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/jackall3n/render-go"
+	"github.com/jackall3n/terraform-provider-render/render/api"
+	"github.com/jackall3n/terraform-provider-render/render/datasources"
 	"github.com/jackall3n/terraform-provider-render/render/types"
 )
 
 var host = "https://api.render.com/v1"
 
-func createContext(ctx context.Context, client *render.ClientWithResponses, email string) (*types.Context, error) {
-	c := &types.Context{Client: client}
+// ownerConfig holds the provider attributes used to resolve the default owner,
+// in order of precedence.
+type ownerConfig struct {
+	ID    string
+	Name  string
+	Email string
+}
+
+func createContext(ctx context.Context, client *render.ClientWithResponses, apiClient *api.Client, config ownerConfig) (*types.Context, error) {
+	c := &types.Context{Client: client, API: apiClient}
 
-	if email == "" {
+	var owner *render.Owner
+	var err error
+
+	switch {
+	case config.ID != "":
+		owner, err = getOwnerByID(ctx, client, config.ID)
+	case config.Name != "":
+		owner, err = getOwnerByName(ctx, client, config.Name)
+	case config.Email != "":
+		owner, err = getOwnerByEmail(ctx, client, config.Email)
+	default:
 		return c, nil
 	}
 
-	tflog.Debug(ctx, "getting owner")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get owner: %s", err.Error())
+	}
+
+	c.Owner = owner
+
+	return c, nil
+}
 
-	owner, err := getOwner(ctx, client, email)
+func getOwnerByID(ctx context.Context, client *render.ClientWithResponses, id string) (*render.Owner, error) {
+	tflog.Debug(ctx, fmt.Sprintf("getting owner with id: %s", id))
+
+	response, err := client.GetOwnerWithResponse(ctx, id)
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to get owner: %s", err.Error())
+		return nil, err
 	}
 
-	if owner == nil {
-		return nil, fmt.Errorf("no owner was found for email [%s]", email)
+	if response.StatusCode() == http.StatusNotFound {
+		return nil, fmt.Errorf("no owner was found for id [%s]", id)
 	}
 
-	c.Owner = owner
+	if response.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("%s %s", response.Status(), string(response.Body))
+	}
 
-	return c, nil
+	return response.JSON200, nil
 }
 
-func getOwner(ctx context.Context, client *render.ClientWithResponses, email string) (*render.Owner, error) {
-	tflog.Debug(ctx, fmt.Sprintf("getting owners with email: %s", email))
+func getOwnerByName(ctx context.Context, client *render.ClientWithResponses, name string) (*render.Owner, error) {
+	tflog.Debug(ctx, fmt.Sprintf("getting owners with name: %s", name))
 
-	response, err := client.GetOwnersWithResponse(ctx, &render.GetOwnersParams{
-		Email: &[]string{email},
-	})
+	owners, err := datasources.GetOwners(ctx, client, &render.GetOwnersParams{Name: &[]string{name}})
 
 	if err != nil {
 		return nil, err
 	}
 
-	owners := *response.JSON200
+	var matches []render.Owner
+
+	for _, owner := range owners {
+		if owner.Name != nil && *owner.Name == name {
+			matches = append(matches, owner)
+		}
+	}
 
-	if len(owners) == 0 {
-		return nil, fmt.Errorf("no owners found for email [%s]", email)
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("no owner was found for name [%s]", name)
 	}
 
-	owner := owners[0].Owner
+	if len(matches) > 1 {
+		ids := make([]string, len(matches))
+
+		for i, owner := range matches {
+			ids[i] = owner.Id
+		}
+
+		return nil, fmt.Errorf("the name [%s] matches %d owners (%s), use owner_id instead", name, len(matches), strings.Join(ids, ", "))
+	}
+
+	return &matches[0], nil
+}
+
+func getOwnerByEmail(ctx context.Context, client *render.ClientWithResponses, email string) (*render.Owner, error) {
+	tflog.Debug(ctx, fmt.Sprintf("getting owners with email: %s", email))
+
+	owners, err := datasources.GetOwners(ctx, client, &render.GetOwnersParams{Email: &[]string{email}})
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, owner := range owners {
+		if owner.Email != nil && strings.EqualFold(*owner.Email, email) {
+			return &owner, nil
+		}
+	}
 
-	return owner, nil
+	return nil, fmt.Errorf("no owner was found for email [%s]", email)
 }
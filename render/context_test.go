@@ -0,0 +1,97 @@
+package render
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/jackall3n/render-go"
+	"github.com/jackall3n/terraform-provider-render/internal/fakerender"
+)
+
+func newOwnersServer(t *testing.T) (*fakerender.Server, *render.ClientWithResponses) {
+	t.Helper()
+
+	server := fakerender.New()
+	t.Cleanup(server.Close)
+
+	user, team := render.OwnerTypeUser, render.OwnerTypeTeam
+	owner := func(id string, name string, email string, ownerType *render.OwnerType) render.Owner {
+		return render.Owner{Id: id, Name: &name, Email: &email, Type: ownerType}
+	}
+
+	server.AddOwner(owner("usr-1", "Jane", "jane@example.com", &user))
+	server.AddOwner(owner("tea-1", "Platform", "jane@example.com", &team))
+	server.AddOwner(owner("tea-2", "Staging", "jane@example.com", &team))
+	server.AddOwner(owner("tea-3", "Staging", "jane@example.com", &team))
+
+	client, err := render.NewClientWithResponses(server.URL)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return server, client
+}
+
+func TestCreateContextResolvesOwner(t *testing.T) {
+	_, client := newOwnersServer(t)
+
+	tests := []struct {
+		name   string
+		config ownerConfig
+		owner  string
+	}{
+		{name: "none", config: ownerConfig{}, owner: ""},
+		{name: "email", config: ownerConfig{Email: "jane@example.com"}, owner: "usr-1"},
+		{name: "name over email", config: ownerConfig{Name: "Platform", Email: "jane@example.com"}, owner: "tea-1"},
+		{name: "id over name", config: ownerConfig{ID: "tea-2", Name: "Platform"}, owner: "tea-2"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, err := createContext(context.Background(), client, nil, test.config)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if test.owner == "" {
+				if c.Owner != nil {
+					t.Fatalf("expected no owner, got %s", c.Owner.Id)
+				}
+
+				return
+			}
+
+			if c.Owner == nil || c.Owner.Id != test.owner {
+				t.Fatalf("expected owner %s, got %+v", test.owner, c.Owner)
+			}
+		})
+	}
+}
+
+func TestCreateContextOwnerErrors(t *testing.T) {
+	_, client := newOwnersServer(t)
+
+	tests := []struct {
+		name   string
+		config ownerConfig
+		error  string
+	}{
+		{name: "ambiguous name", config: ownerConfig{Name: "Staging"}, error: "matches 2 owners (tea-2, tea-3), use owner_id instead"},
+		{name: "unknown name", config: ownerConfig{Name: "Production"}, error: "no owner was found for name [Production]"},
+		{name: "unknown id", config: ownerConfig{ID: "tea-9"}, error: "no owner was found for id [tea-9]"},
+		{name: "unknown email", config: ownerConfig{Email: "john@example.com"}, error: "no owner was found for email [john@example.com]"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := createContext(context.Background(), client, nil, test.config)
+
+			if err == nil || !strings.Contains(err.Error(), test.error) {
+				t.Fatalf("expected error containing %q, got %v", test.error, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package datasources
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jackall3n/terraform-provider-render/render/api"
+	"github.com/jackall3n/terraform-provider-render/render/models"
+	"github.com/jackall3n/terraform-provider-render/render/types"
+	"github.com/jackall3n/terraform-provider-render/render/utils"
+	"net/http"
+)
+
+func BlueprintDataSource() datasource.DataSource {
+	return &blueprintDataSource{}
+}
+
+type blueprintDataSource struct {
+	api     *api.Client
+	context *types.Context
+}
+
+func (d *blueprintDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_blueprint"
+}
+
+func (d *blueprintDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	ctx, ok := req.ProviderData.(*types.Context)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *types.Context, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.context = ctx
+	d.api = ctx.API
+}
+
+// Schema returns the schema information for a blueprint data source
+func (_ *blueprintDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `Provides information about an existing blueprint and the resources it created.`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The ID of the blueprint. Either `id` or `name` is required.",
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("id"), path.MatchRoot("name")),
+				},
+			},
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The name of the blueprint, looked up among the blueprints of `owner`.",
+			},
+			"owner": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The ID of the user or team the blueprint belongs to, defaults to the owner of the provider.",
+			},
+			"repo":      schema.StringAttribute{Computed: true},
+			"branch":    schema.StringAttribute{Computed: true},
+			"path":      schema.StringAttribute{Computed: true},
+			"auto_sync": schema.BoolAttribute{Computed: true},
+			"status":    schema.StringAttribute{Computed: true, Description: "One of `created`, `paused`, `in_sync`, `syncing` or `error`."},
+			"last_sync": schema.StringAttribute{Computed: true},
+			"service_ids": schema.ListAttribute{
+				Description: "The IDs of the services created by the blueprint.",
+				Computed:    true,
+				ElementType: basetypes.StringType{},
+			},
+			"database_ids": schema.ListAttribute{
+				Description: "The IDs of the PostgreSQL and Redis instances created by the blueprint.",
+				Computed:    true,
+				ElementType: basetypes.StringType{},
+			},
+			"resources": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id":   schema.StringAttribute{Computed: true},
+						"name": schema.StringAttribute{Computed: true},
+						"type": schema.StringAttribute{Computed: true},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *blueprintDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data models.BlueprintDataSource
+
+	diags := req.Config.Get(ctx, &data)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := data.ID.ValueString()
+
+	if data.ID.IsNull() {
+		var err error
+
+		id, err = d.findBlueprintId(ctx, data)
+
+		if err != nil {
+			resp.Diagnostics.AddError("failed to get blueprint", err.Error())
+			return
+		}
+	}
+
+	// The list endpoint doesn't return the resources of a blueprint.
+	response, err := d.api.GetBlueprintWithResponse(ctx, id)
+
+	if err != nil {
+		resp.Diagnostics.AddError("failed to get blueprint", err.Error())
+		return
+	}
+
+	if response.StatusCode() != http.StatusOK {
+		resp.Diagnostics.AddError("failed to get blueprint", fmt.Sprintf("%s %s", response.Status(), string(response.Body)))
+		return
+	}
+
+	result := data.FromResponse(*response.JSON200)
+
+	tflog.Trace(ctx, "read blueprint", map[string]interface{}{
+		"id":   result.ID.ValueString(),
+		"name": result.Name.ValueString(),
+	})
+
+	diags = resp.State.Set(ctx, result)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// findBlueprintId looks up the blueprint of the owner by name.
+func (d *blueprintDataSource) findBlueprintId(ctx context.Context, data models.BlueprintDataSource) (string, error) {
+	ownerId := data.Owner.ValueString()
+
+	if data.Owner.IsNull() {
+		if d.context.Owner == nil {
+			return "", fmt.Errorf("'owner' is required if the provider has no 'owner_id', 'owner_name' or 'email'")
+		}
+
+		ownerId = d.context.Owner.Id
+	}
+
+	blueprints, err := utils.Paginate(func(cursor *string) (utils.Page[api.Blueprint, string], error) {
+		var page utils.Page[api.Blueprint, string]
+
+		response, err := d.api.ListBlueprintsWithResponse(ctx, ownerId, cursor, utils.PageLimit)
+
+		if err != nil {
+			return page, err
+		}
+
+		if response.StatusCode() != http.StatusOK {
+			return page, fmt.Errorf("%s %s", response.Status(), string(response.Body))
+		}
+
+		for _, item := range *response.JSON200 {
+			if item.Blueprint != nil {
+				page.Items = append(page.Items, *item.Blueprint)
+			}
+
+			page.Cursor = item.Cursor
+		}
+
+		page.Size = len(*response.JSON200)
+
+		return page, nil
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	for _, blueprint := range blueprints {
+		if blueprint.Name == data.Name.ValueString() {
+			return blueprint.Id, nil
+		}
+	}
+
+	return "", fmt.Errorf("no blueprint was found for name [%s]", data.Name.ValueString())
+}
@@ -9,7 +9,7 @@ import (
 	"github.com/jackall3n/render-go"
 	"github.com/jackall3n/terraform-provider-render/render/models"
 	"github.com/jackall3n/terraform-provider-render/render/types"
-	"net/http"
+	"strings"
 )
 
 func OwnerDataSource() datasource.DataSource {
@@ -78,7 +78,7 @@ func (d *ownerDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	response, err := d.client.GetOwnersWithResponse(ctx, &render.GetOwnersParams{
+	owners, err := GetOwners(ctx, d.client, &render.GetOwnersParams{
 		Email: &[]string{data.Email.ValueString()},
 	})
 
@@ -87,12 +87,19 @@ func (d *ownerDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	if response.StatusCode() != http.StatusOK {
-		resp.Diagnostics.AddError("failed to get owners", fmt.Sprintf("%s %s", response.Status(), string(response.Body)))
-		return
+	var owner *render.Owner
+
+	for i := range owners {
+		if owners[i].Email != nil && strings.EqualFold(*owners[i].Email, data.Email.ValueString()) {
+			owner = &owners[i]
+			break
+		}
 	}
 
-	owner := (*response.JSON200)[0].Owner
+	if owner == nil {
+		resp.Diagnostics.AddError("owner not found", fmt.Sprintf("no owner was found for email [%s]", data.Email.ValueString()))
+		return
+	}
 
 	result := data.FromResponse(*owner)
 
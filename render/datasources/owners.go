@@ -0,0 +1,154 @@
+package datasources
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jackall3n/render-go"
+	"github.com/jackall3n/terraform-provider-render/render/models"
+	"github.com/jackall3n/terraform-provider-render/render/types"
+	"github.com/jackall3n/terraform-provider-render/render/utils"
+	"net/http"
+)
+
+func OwnersDataSource() datasource.DataSource {
+	return &ownersDataSource{}
+}
+
+type ownersDataSource struct {
+	client  *render.ClientWithResponses
+	context *types.Context
+}
+
+func (d *ownersDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_owners"
+}
+
+func (d *ownersDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	ctx, ok := req.ProviderData.(*types.Context)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *types.Context, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.context = ctx
+	d.client = ctx.Client
+}
+
+// Schema returns the schema information for an owners data source
+func (_ *ownersDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `Lists every user and team the API key has access to.`,
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Description: "Only list owners with this name.",
+				Optional:    true,
+			},
+			"type": schema.StringAttribute{
+				Description: "Only list owners of this type, either `user` or `team`.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(string(render.OwnerTypeUser), string(render.OwnerTypeTeam)),
+				},
+			},
+			"owners": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id":    schema.StringAttribute{Computed: true},
+						"name":  schema.StringAttribute{Computed: true},
+						"email": schema.StringAttribute{Computed: true},
+						"type":  schema.StringAttribute{Computed: true},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ownersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data models.Owners
+
+	diags := req.Config.Get(ctx, &data)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := &render.GetOwnersParams{}
+
+	if !data.Name.IsNull() {
+		params.Name = &[]string{data.Name.ValueString()}
+	}
+
+	owners, err := GetOwners(ctx, d.client, params)
+
+	if err != nil {
+		resp.Diagnostics.AddError("failed to get owners", err.Error())
+		return
+	}
+
+	result := data.FromResponse(owners)
+
+	tflog.Trace(ctx, "read owners", map[string]interface{}{
+		"count": len(result.Owners),
+	})
+
+	diags = resp.State.Set(ctx, result)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// GetOwners pages through the owners matching params.
+func GetOwners(ctx context.Context, client *render.ClientWithResponses, params *render.GetOwnersParams) ([]render.Owner, error) {
+	limit := render.LimitParam(utils.PageLimit)
+	params.Limit = &limit
+
+	return utils.Paginate(func(cursor *[]byte) (utils.Page[render.Owner, []byte], error) {
+		var page utils.Page[render.Owner, []byte]
+
+		params.Cursor = cursor
+
+		response, err := client.GetOwnersWithResponse(ctx, params)
+
+		if err != nil {
+			return page, err
+		}
+
+		if response.StatusCode() != http.StatusOK {
+			return page, fmt.Errorf("%s %s", response.Status(), string(response.Body))
+		}
+
+		for _, item := range *response.JSON200 {
+			if item.Owner != nil {
+				page.Items = append(page.Items, *item.Owner)
+			}
+
+			page.Cursor = item.Cursor
+		}
+
+		page.Size = len(*response.JSON200)
+
+		return page, nil
+	})
+}
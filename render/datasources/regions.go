@@ -0,0 +1,50 @@
+package datasources
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/jackall3n/terraform-provider-render/render/models"
+	"github.com/jackall3n/terraform-provider-render/render/utils"
+)
+
+func RegionsDataSource() datasource.DataSource {
+	return &regionsDataSource{}
+}
+
+type regionsDataSource struct{}
+
+func (d *regionsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_regions"
+}
+
+// Schema returns the schema information for a regions data source
+func (_ *regionsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `Lists every region services can be deployed to.`,
+		Attributes: map[string]schema.Attribute{
+			"regions": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id":   schema.StringAttribute{Computed: true, Description: "The value to use as `region`, e.g. `oregon`."},
+						"name": schema.StringAttribute{Computed: true, Description: "The display name, e.g. `Oregon (US West)`."},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read lists the regions known to the provider, as the API has no endpoint for them.
+func (d *regionsDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
+	result := models.Regions{}.FromRegions(utils.Regions(), utils.RegionName)
+
+	diags := resp.State.Set(ctx, result)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
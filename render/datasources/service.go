@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/jackall3n/render-go"
+	"github.com/jackall3n/terraform-provider-render/render/api"
 	"github.com/jackall3n/terraform-provider-render/render/models"
 	"github.com/jackall3n/terraform-provider-render/render/types"
 	"net/http"
@@ -92,7 +93,7 @@ func (d *serviceDataSource) Read(ctx context.Context, req datasource.ReadRequest
 
 	service := response.JSON200
 
-	result := data.FromResponse(*service)
+	result := data.FromResponse(api.Service{Service: *service})
 
 	tflog.Trace(ctx, "read service", map[string]interface{}{
 		"id":   result.ID.ValueString(),
@@ -0,0 +1,136 @@
+package datasources
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jackall3n/render-go"
+	"github.com/jackall3n/terraform-provider-render/render/models"
+	"github.com/jackall3n/terraform-provider-render/render/types"
+	"github.com/jackall3n/terraform-provider-render/render/utils"
+	"net/http"
+)
+
+func ServiceCustomDomainsDataSource() datasource.DataSource {
+	return &serviceCustomDomainsDataSource{}
+}
+
+type serviceCustomDomainsDataSource struct {
+	client  *render.ClientWithResponses
+	context *types.Context
+}
+
+func (d *serviceCustomDomainsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_service_custom_domains"
+}
+
+func (d *serviceCustomDomainsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	ctx, ok := req.ProviderData.(*types.Context)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *types.Context, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.context = ctx
+	d.client = ctx.Client
+}
+
+// Schema returns the schema information for a service custom domains data source
+func (_ *serviceCustomDomainsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `Lists every custom domain attached to a service.`,
+		Attributes: map[string]schema.Attribute{
+			"service_id": schema.StringAttribute{
+				Required: true,
+			},
+			"domains": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id":                  schema.StringAttribute{Computed: true},
+						"domain_name":         schema.StringAttribute{Computed: true},
+						"domain_type":         schema.StringAttribute{Computed: true},
+						"verification_status": schema.StringAttribute{Computed: true},
+						"redirect_for_name":   schema.StringAttribute{Computed: true},
+						"public_suffix":       schema.StringAttribute{Computed: true},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *serviceCustomDomainsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data models.ServiceCustomDomains
+
+	diags := req.Config.Get(ctx, &data)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	limit := render.LimitParam(utils.PageLimit)
+	params := &render.GetCustomDomainsParams{Limit: &limit}
+
+	domains, err := utils.Paginate(func(cursor *[]byte) (utils.Page[render.CustomDomain, []byte], error) {
+		var page utils.Page[render.CustomDomain, []byte]
+
+		params.Cursor = cursor
+
+		response, err := d.client.GetCustomDomainsWithResponse(ctx, data.ServiceID.ValueString(), params)
+
+		if err != nil {
+			return page, err
+		}
+
+		if response.StatusCode() != http.StatusOK {
+			return page, fmt.Errorf("%s %s", response.Status(), string(response.Body))
+		}
+
+		for _, item := range *response.JSON200 {
+			if item.CustomDomain != nil {
+				page.Items = append(page.Items, *item.CustomDomain)
+			}
+
+			page.Cursor = item.Cursor
+		}
+
+		page.Size = len(*response.JSON200)
+
+		return page, nil
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("failed to get custom domains", err.Error())
+		return
+	}
+
+	result := data.FromResponse(domains)
+
+	tflog.Trace(ctx, "read custom domains", map[string]interface{}{
+		"service_id": result.ServiceID.ValueString(),
+		"count":      len(result.Domains),
+	})
+
+	diags = resp.State.Set(ctx, result)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
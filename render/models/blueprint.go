@@ -0,0 +1,180 @@
+package models
+
+import (
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jackall3n/render-go"
+	"github.com/jackall3n/terraform-provider-render/render/api"
+	"github.com/jackall3n/terraform-provider-render/render/utils"
+)
+
+type Blueprint struct {
+	ID          types.String `tfsdk:"id"`
+	Owner       types.String `tfsdk:"owner"`
+	Name        types.String `tfsdk:"name"`
+	Repo        types.String `tfsdk:"repo"`
+	Branch      types.String `tfsdk:"branch"`
+	Path        types.String `tfsdk:"path"`
+	AutoSync    types.Bool   `tfsdk:"auto_sync"`
+	Status      types.String `tfsdk:"status"`
+	LastSync    types.String `tfsdk:"last_sync"`
+	ServiceIDs  types.List   `tfsdk:"service_ids"`
+	DatabaseIDs types.List   `tfsdk:"database_ids"`
+	Resources   types.List   `tfsdk:"resources"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// BlueprintDataSource is a Blueprint without `timeouts`.
+type BlueprintDataSource struct {
+	ID          types.String `tfsdk:"id"`
+	Owner       types.String `tfsdk:"owner"`
+	Name        types.String `tfsdk:"name"`
+	Repo        types.String `tfsdk:"repo"`
+	Branch      types.String `tfsdk:"branch"`
+	Path        types.String `tfsdk:"path"`
+	AutoSync    types.Bool   `tfsdk:"auto_sync"`
+	Status      types.String `tfsdk:"status"`
+	LastSync    types.String `tfsdk:"last_sync"`
+	ServiceIDs  types.List   `tfsdk:"service_ids"`
+	DatabaseIDs types.List   `tfsdk:"database_ids"`
+	Resources   types.List   `tfsdk:"resources"`
+}
+
+var BlueprintResourceAttributeTypes = map[string]attr.Type{
+	"id":   types.StringType,
+	"name": types.StringType,
+	"type": types.StringType,
+}
+
+// databaseResourceTypes are the blueprint resource types listed in `database_ids`.
+var databaseResourceTypes = map[string]bool{
+	"postgres": true,
+	"redis":    true,
+}
+
+func (b Blueprint) FromResponse(response api.Blueprint) Blueprint {
+	result := Blueprint{
+		ID:       types.StringValue(response.Id),
+		Owner:    b.Owner,
+		Name:     types.StringValue(response.Name),
+		Repo:     types.StringValue(response.Repo),
+		Branch:   types.StringValue(response.Branch),
+		Path:     types.StringValue(response.Path),
+		AutoSync: types.BoolValue(response.AutoSync),
+		Status:   types.StringValue(string(response.Status)),
+		LastSync: fromTime(response.LastSync),
+		Timeouts: b.Timeouts,
+	}
+
+	if response.OwnerId != "" {
+		result.Owner = types.StringValue(response.OwnerId)
+	}
+
+	// Blueprints are matched to `repo` however it is spelled, keep the configured spelling.
+	if utils.SameRepo(b.Repo.ValueString(), response.Repo) {
+		result.Repo = b.Repo
+	}
+
+	result.ServiceIDs, result.DatabaseIDs, result.Resources = fromBlueprintResources(response.Resources)
+
+	return result
+}
+
+// ToBlueprintPATCH only sends the settings set in the configuration, the others keep
+// the values of the blueprint instance.
+func (b Blueprint) ToBlueprintPATCH() api.BlueprintPATCH {
+	return api.BlueprintPATCH{
+		Name:     stringKnown(b.Name),
+		AutoSync: boolKnown(b.AutoSync),
+		Path:     stringKnown(b.Path),
+	}
+}
+
+func (b BlueprintDataSource) FromResponse(response api.Blueprint) BlueprintDataSource {
+	result := Blueprint{Owner: b.Owner}.FromResponse(response)
+
+	return BlueprintDataSource{
+		ID:          result.ID,
+		Owner:       result.Owner,
+		Name:        result.Name,
+		Repo:        result.Repo,
+		Branch:      result.Branch,
+		Path:        result.Path,
+		AutoSync:    result.AutoSync,
+		Status:      result.Status,
+		LastSync:    result.LastSync,
+		ServiceIDs:  result.ServiceIDs,
+		DatabaseIDs: result.DatabaseIDs,
+		Resources:   result.Resources,
+	}
+}
+
+// fromBlueprintResources splits the resources of a blueprint into service IDs and
+// database IDs, and lists all of them, e.g. environment groups, in `resources`.
+func fromBlueprintResources(resources []api.BlueprintResource) (types.List, types.List, types.List) {
+	serviceIds := []attr.Value{}
+	databaseIds := []attr.Value{}
+	items := []attr.Value{}
+
+	for _, resource := range resources {
+		switch {
+		case isServiceType(resource.Type):
+			serviceIds = append(serviceIds, types.StringValue(resource.Id))
+		case databaseResourceTypes[resource.Type]:
+			databaseIds = append(databaseIds, types.StringValue(resource.Id))
+		}
+
+		item, _ := types.ObjectValue(BlueprintResourceAttributeTypes, map[string]attr.Value{
+			"id":   types.StringValue(resource.Id),
+			"name": types.StringValue(resource.Name),
+			"type": types.StringValue(resource.Type),
+		})
+
+		items = append(items, item)
+	}
+
+	services, _ := types.ListValue(types.StringType, serviceIds)
+	databases, _ := types.ListValue(types.StringType, databaseIds)
+	list, _ := types.ListValue(types.ObjectType{AttrTypes: BlueprintResourceAttributeTypes}, items)
+
+	return services, databases, list
+}
+
+func isServiceType(t string) bool {
+	switch render.ServiceType(t) {
+	case render.StaticSite, render.WebService, render.PrivateService, render.BackgroundWorker, render.CronJob:
+		return true
+	}
+
+	return false
+}
+
+func stringKnown(value types.String) *string {
+	if value.IsUnknown() {
+		return nil
+	}
+
+	return stringOptional(value)
+}
+
+func boolKnown(value types.Bool) *bool {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+
+	v := value.ValueBool()
+
+	return &v
+}
+
+func fromTime(t *time.Time) types.String {
+	if t == nil {
+		return types.StringNull()
+	}
+
+	return types.StringValue(t.UTC().Format(time.RFC3339))
+}
@@ -1,7 +1,6 @@
 package models
 
 import (
-	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/jackall3n/render-go"
 )
@@ -16,8 +15,16 @@ type Owner struct {
 func (o Owner) FromResponse(response render.Owner) Owner {
 	return Owner{
 		ID:    types.StringValue(response.Id),
-		Name:  types.StringValue(*response.Name),
-		Type:  types.StringValue(fmt.Sprintf("%s", response.Type)),
+		Name:  fromStringOptional(response.Name),
+		Type:  fromOwnerType(response.Type),
 		Email: o.Email,
 	}
 }
+
+func fromOwnerType(t *render.OwnerType) types.String {
+	if t == nil {
+		return types.StringNull()
+	}
+
+	return types.StringValue(string(*t))
+}
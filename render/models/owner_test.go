@@ -0,0 +1,27 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jackall3n/render-go"
+)
+
+func TestOwnerFromResponse(t *testing.T) {
+	name := "Platform"
+	team := render.OwnerTypeTeam
+
+	owner := Owner{Email: types.StringValue("platform@example.com")}.FromResponse(render.Owner{Id: "tea-1", Name: &name, Type: &team})
+
+	if owner.Name.ValueString() != "Platform" || owner.Type.ValueString() != "team" || owner.Email.ValueString() != "platform@example.com" {
+		t.Fatalf("unexpected owner %+v", owner)
+	}
+}
+
+func TestOwnerFromResponseWithoutNameOrType(t *testing.T) {
+	owner := Owner{}.FromResponse(render.Owner{Id: "usr-1"})
+
+	if !owner.Name.IsNull() || !owner.Type.IsNull() {
+		t.Fatalf("expected a null name and type, got %+v", owner)
+	}
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jackall3n/render-go"
+)
+
+type Owners struct {
+	Name   types.String `tfsdk:"name"`
+	Type   types.String `tfsdk:"type"`
+	Owners []OwnersItem `tfsdk:"owners"`
+}
+
+type OwnersItem struct {
+	ID    types.String `tfsdk:"id"`
+	Name  types.String `tfsdk:"name"`
+	Email types.String `tfsdk:"email"`
+	Type  types.String `tfsdk:"type"`
+}
+
+// FromResponse keeps the owners that match the name and type filters.
+func (o Owners) FromResponse(owners []render.Owner) Owners {
+	result := Owners{
+		Name:   o.Name,
+		Type:   o.Type,
+		Owners: []OwnersItem{},
+	}
+
+	for _, owner := range owners {
+		item := OwnersItem{
+			ID:    types.StringValue(owner.Id),
+			Name:  fromStringOptional(owner.Name),
+			Email: fromStringOptional(owner.Email),
+			Type:  fromOwnerType(owner.Type),
+		}
+
+		if !o.Name.IsNull() && !item.Name.Equal(o.Name) {
+			continue
+		}
+
+		if !o.Type.IsNull() && !item.Type.Equal(o.Type) {
+			continue
+		}
+
+		result.Owners = append(result.Owners, item)
+	}
+
+	return result
+}
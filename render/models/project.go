@@ -0,0 +1,119 @@
+package models
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jackall3n/terraform-provider-render/render/api"
+)
+
+type Project struct {
+	ID                 types.String `tfsdk:"id"`
+	Owner              types.String `tfsdk:"owner"`
+	Name               types.String `tfsdk:"name"`
+	InitialEnvironment types.String `tfsdk:"initial_environment"`
+	EnvironmentIDs     types.List   `tfsdk:"environment_ids"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+type ProjectEnvironment struct {
+	ID                      types.String `tfsdk:"id"`
+	ProjectID               types.String `tfsdk:"project_id"`
+	Name                    types.String `tfsdk:"name"`
+	Protected               types.Bool   `tfsdk:"protected"`
+	NetworkIsolationEnabled types.Bool   `tfsdk:"network_isolation_enabled"`
+	ServiceIDs              types.List   `tfsdk:"service_ids"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// FromResponse maps a project onto state. Render doesn't return which environment was
+// created with the project, so `initial_environment` is kept.
+func (p Project) FromResponse(response api.Project) Project {
+	result := Project{
+		ID:                 types.StringValue(response.Id),
+		Owner:              p.Owner,
+		Name:               types.StringValue(response.Name),
+		InitialEnvironment: p.InitialEnvironment,
+		EnvironmentIDs:     fromIDs(response.EnvironmentIds),
+		Timeouts:           p.Timeouts,
+	}
+
+	if response.Owner != nil {
+		result.Owner = types.StringValue(response.Owner.Id)
+	}
+
+	return result
+}
+
+func (p Project) ToProjectPOST(ownerId string) api.ProjectPOST {
+	return api.ProjectPOST{
+		Name:         p.Name.ValueString(),
+		OwnerId:      ownerId,
+		Environments: []api.EnvironmentInput{{Name: p.InitialEnvironment.ValueString()}},
+	}
+}
+
+func (e ProjectEnvironment) FromResponse(response api.Environment) ProjectEnvironment {
+	return ProjectEnvironment{
+		ID:                      types.StringValue(response.Id),
+		ProjectID:               types.StringValue(response.ProjectId),
+		Name:                    types.StringValue(response.Name),
+		Protected:               types.BoolValue(response.ProtectedStatus == api.Protected),
+		NetworkIsolationEnabled: types.BoolValue(response.NetworkIsolationEnabled),
+		ServiceIDs:              fromIDs(response.ServiceIds),
+		Timeouts:                e.Timeouts,
+	}
+}
+
+func (e ProjectEnvironment) ToEnvironmentPOST() api.EnvironmentPOST {
+	patch := e.ToEnvironmentPATCH()
+
+	post := api.EnvironmentPOST{
+		EnvironmentInput: api.EnvironmentInput{
+			Name:                    e.Name.ValueString(),
+			NetworkIsolationEnabled: patch.NetworkIsolationEnabled,
+		},
+		ProjectId: e.ProjectID.ValueString(),
+	}
+
+	if patch.ProtectedStatus != nil {
+		post.ProtectedStatus = *patch.ProtectedStatus
+	}
+
+	return post
+}
+
+// ToEnvironmentPATCH only sends the settings set in the configuration, the others keep
+// the values of the environment.
+func (e ProjectEnvironment) ToEnvironmentPATCH() api.EnvironmentPATCH {
+	patch := api.EnvironmentPATCH{
+		Name:                    stringKnown(e.Name),
+		NetworkIsolationEnabled: boolKnown(e.NetworkIsolationEnabled),
+	}
+
+	if protected := boolKnown(e.Protected); protected != nil {
+		status := api.Unprotected
+
+		if *protected {
+			status = api.Protected
+		}
+
+		patch.ProtectedStatus = &status
+	}
+
+	return patch
+}
+
+func fromIDs(ids []string) types.List {
+	values := make([]attr.Value, len(ids))
+
+	for i, id := range ids {
+		values[i] = types.StringValue(id)
+	}
+
+	list, _ := types.ListValue(types.StringType, values)
+
+	return list
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type Regions struct {
+	Regions []RegionsItem `tfsdk:"regions"`
+}
+
+type RegionsItem struct {
+	ID   types.String `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+}
+
+func (r Regions) FromRegions(regions []string, name func(string) string) Regions {
+	result := Regions{Regions: []RegionsItem{}}
+
+	for _, region := range regions {
+		result.Regions = append(result.Regions, RegionsItem{
+			ID:   types.StringValue(region),
+			Name: types.StringValue(name(region)),
+		})
+	}
+
+	return result
+}
@@ -1,23 +1,43 @@
 package models
 
 import (
+	"encoding/json"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/jackall3n/render-go"
+	"github.com/jackall3n/terraform-provider-render/render/api"
 	"github.com/jackall3n/terraform-provider-render/render/utils"
 )
 
 type Service struct {
-	ID                    types.String           `tfsdk:"id"`
-	Name                  types.String           `tfsdk:"name"`
-	Type                  types.String           `tfsdk:"type"`
-	Repo                  types.String           `tfsdk:"repo"`
-	Branch                types.String           `tfsdk:"branch"`
-	Owner                 types.String           `tfsdk:"owner"`
-	AutoDeploy            types.Bool             `tfsdk:"auto_deploy"`
-	WebServiceDetails     *WebServiceDetails     `tfsdk:"web_service_details"`
-	StaticSiteDetails     *StaticSiteDetails     `tfsdk:"static_site_details"`
-	PrivateServiceDetails *PrivateServiceDetails `tfsdk:"private_service_details"`
+	ID                      types.String             `tfsdk:"id"`
+	Name                    types.String             `tfsdk:"name"`
+	Type                    types.String             `tfsdk:"type"`
+	Repo                    types.String             `tfsdk:"repo"`
+	Branch                  types.String             `tfsdk:"branch"`
+	Owner                   types.String             `tfsdk:"owner"`
+	AutoDeploy              types.Bool               `tfsdk:"auto_deploy"`
+	RootDirectory           types.String             `tfsdk:"root_directory"`
+	EnvironmentID           types.String             `tfsdk:"environment_id"`
+	BuildFilter             *BuildFilter             `tfsdk:"build_filter"`
+	Previews                *Previews                `tfsdk:"previews"`
+	WebServiceDetails       *WebServiceDetails       `tfsdk:"web_service_details"`
+	StaticSiteDetails       *StaticSiteDetails       `tfsdk:"static_site_details"`
+	PrivateServiceDetails   *PrivateServiceDetails   `tfsdk:"private_service_details"`
+	BackgroundWorkerDetails *BackgroundWorkerDetails `tfsdk:"background_worker_details"`
+	Timeouts                timeouts.Value           `tfsdk:"timeouts"`
+}
+
+type BuildFilter struct {
+	Paths        []types.String `tfsdk:"paths"`
+	IgnoredPaths []types.String `tfsdk:"ignored_paths"`
+}
+
+type Previews struct {
+	Generation      types.String `tfsdk:"generation"`
+	ExpireAfterDays types.Int64  `tfsdk:"expire_after_days"`
+	Plan            types.String `tfsdk:"plan"`
 }
 
 type WebServiceDetails struct {
@@ -26,6 +46,9 @@ type WebServiceDetails struct {
 	Plan                       types.String             `tfsdk:"plan"`
 	PullRequestPreviewsEnabled types.Bool               `tfsdk:"pull_request_previews_enabled"`
 	HealthCheckPath            types.String             `tfsdk:"health_check_path"`
+	PreDeployCommand           types.String             `tfsdk:"pre_deploy_command"`
+	MaxShutdownDelaySeconds    types.Int64              `tfsdk:"max_shutdown_delay_seconds"`
+	DockerCommand              types.String             `tfsdk:"docker_command"`
 	Native                     *WebServiceDetailsNative `tfsdk:"native"`
 	Url                        types.String             `tfsdk:"url"`
 }
@@ -47,84 +70,175 @@ type PrivateServiceDetails struct {
 	Region                     types.String `tfsdk:"region"`
 	Plan                       types.String `tfsdk:"plan"`
 	PullRequestPreviewsEnabled types.Bool   `tfsdk:"pull_request_previews_enabled"`
+	PreDeployCommand           types.String `tfsdk:"pre_deploy_command"`
 	Url                        types.String `tfsdk:"url"`
 	Disk                       *Disk        `tfsdk:"disk"`
 }
 
+type BackgroundWorkerDetails struct {
+	Env              types.String             `tfsdk:"env"`
+	Region           types.String             `tfsdk:"region"`
+	Plan             types.String             `tfsdk:"plan"`
+	PreDeployCommand types.String             `tfsdk:"pre_deploy_command"`
+	Native           *WebServiceDetailsNative `tfsdk:"native"`
+	Disk             *Disk                    `tfsdk:"disk"`
+}
+
 type Disk struct {
 	Name      types.String `tfsdk:"name"`
 	MountPath types.String `tfsdk:"mount_path"`
 	SizeGB    types.Int64  `tfsdk:"size_gb"`
 }
 
-func (s Service) FromResponse(response render.Service) Service {
-	serviceType := *response.Type
+func (s Service) FromResponse(response api.Service) Service {
+	var serviceType render.ServiceType
+
+	if response.Type != nil {
+		serviceType = *response.Type
+	}
+
+	serviceDetails := render.Service_ServiceDetails{}
+
+	if response.ServiceDetails != nil {
+		serviceDetails = *response.ServiceDetails
+	}
 
 	service := Service{
-		ID:     fromStringOptional(response.Id),
-		Name:   fromStringOptional(response.Name),
-		Type:   fromServiceType(response.Type),
-		Repo:   fromStringOptional(response.Repo),
-		Branch: fromStringOptional(response.Branch),
-		Owner:  fromStringOptional(response.OwnerId),
+		ID:         fromStringOptional(response.Id),
+		Name:       fromStringOptional(response.Name),
+		Type:       fromServiceType(response.Type),
+		Repo:       fromStringOptional(response.Repo),
+		Branch:     fromStringOptional(response.Branch),
+		Owner:      fromStringOptional(response.OwnerId),
+		AutoDeploy: fromYesNo(response.AutoDeploy),
+		Timeouts:   s.Timeouts,
+
+		RootDirectory: fromStringOptionalNil(response.RootDir),
+		BuildFilter:   fromBuildFilter(response.BuildFilter, s.BuildFilter),
+		EnvironmentID: types.StringNull(),
+	}
+
+	// The environment is only read back when managed, services placed in one outside of Terraform keep it
+	if !s.EnvironmentID.IsNull() {
+		service.EnvironmentID = fromStringOptional(response.EnvironmentId)
+	}
+
+	// Previews are only read back when managed, like `pull_request_previews_enabled`
+	if s.Previews != nil {
+		extra, _ := api.DecodeServiceDetails(serviceDetails)
+		service.Previews = fromPreviews(extra.Previews)
 	}
 
 	if serviceType == render.WebService {
-		details, _ := response.ServiceDetails.AsWebServiceDetails()
+		details, _ := serviceDetails.AsWebServiceDetails()
+
+		extra, _ := api.DecodeServiceDetails(serviceDetails)
 
 		service.WebServiceDetails = &WebServiceDetails{
-			Region:          fromRegion(details.Region),
-			Env:             fromServiceEnv(details.Env),
-			Plan:            fromStringOptional(details.Plan),
-			HealthCheckPath: fromStringOptional(details.HealthCheckPath),
-			Url:             fromStringOptional(details.Url),
+			Region:                  fromRegion(details.Region),
+			Env:                     fromServiceEnv(details.Env),
+			Plan:                    fromStringOptional(details.Plan),
+			HealthCheckPath:         fromStringOptional(details.HealthCheckPath),
+			PreDeployCommand:        types.StringNull(),
+			MaxShutdownDelaySeconds: fromIntOptional(extra.MaxShutdownDelaySeconds),
+			DockerCommand:           types.StringNull(),
+			Url:                     fromStringOptional(details.Url),
+		}
+
+		if s.WebServiceDetails != nil {
+			service.WebServiceDetails.PullRequestPreviewsEnabled = fromManagedYesNo(details.PullRequestPreviewsEnabled, s.WebServiceDetails.PullRequestPreviewsEnabled)
 		}
 
-		native, err := details.EnvSpecificDetails.AsNativeEnvironmentDetails()
+		if details.EnvSpecificDetails != nil {
+			if envDetails, err := api.DecodeEnvSpecificDetails(*details.EnvSpecificDetails); err == nil {
+				service.WebServiceDetails.PreDeployCommand = fromStringOptionalNil(envDetails.PreDeployCommand)
+				service.WebServiceDetails.Native = fromNative(details.Env, envDetails)
 
-		if err == nil {
-			service.WebServiceDetails.Native = &WebServiceDetailsNative{
-				BuildCommand: fromStringOptional(native.BuildCommand),
-				StartCommand: fromStringOptional(native.StartCommand),
+				if details.Env != nil && *details.Env == render.Docker {
+					service.WebServiceDetails.DockerCommand = fromStringOptionalNil(envDetails.DockerCommand)
+				}
 			}
 		}
 	}
 
 	if serviceType == render.PrivateService {
-		details, _ := response.ServiceDetails.AsPrivateServiceDetails()
+		details, _ := serviceDetails.AsPrivateServiceDetails()
 
 		service.PrivateServiceDetails = &PrivateServiceDetails{
-			Region: fromRegion(details.Region),
-			Env:    fromServiceEnv(details.Env),
-			Plan:   fromStringOptional(details.Plan),
-			Url:    fromStringOptional(details.Url),
+			Region:           fromRegion(details.Region),
+			Env:              fromServiceEnv(details.Env),
+			Plan:             fromStringOptional(details.Plan),
+			PreDeployCommand: types.StringNull(),
+			Url:              fromStringOptional(details.Url),
+		}
+
+		if details.EnvSpecificDetails != nil {
+			if envDetails, err := api.DecodeEnvSpecificDetails(*details.EnvSpecificDetails); err == nil {
+				service.PrivateServiceDetails.PreDeployCommand = fromStringOptionalNil(envDetails.PreDeployCommand)
+			}
+		}
+
+		if s.PrivateServiceDetails != nil {
+			service.PrivateServiceDetails.PullRequestPreviewsEnabled = fromManagedYesNo(details.PullRequestPreviewsEnabled, s.PrivateServiceDetails.PullRequestPreviewsEnabled)
 		}
 
 		if details.Disk != nil {
-			service.PrivateServiceDetails.Disk = &Disk{
-				Name: fromStringOptional(details.Disk.Name),
+			var prior *Disk
 
-				// Hack because the OpenAPI doesn't specify these fields as return.. I should check this
-				MountPath: s.PrivateServiceDetails.Disk.MountPath,
-				SizeGB:    s.PrivateServiceDetails.Disk.SizeGB,
+			if s.PrivateServiceDetails != nil {
+				prior = s.PrivateServiceDetails.Disk
 			}
+
+			service.PrivateServiceDetails.Disk = fromDisk(details.Disk.Name, prior)
+		}
+	}
+
+	if serviceType == render.BackgroundWorker {
+		details, _ := serviceDetails.AsBackgroundWorkerDetails()
+
+		service.BackgroundWorkerDetails = &BackgroundWorkerDetails{
+			Region:           fromRegion(details.Region),
+			Env:              fromServiceEnv(details.Env),
+			Plan:             fromStringOptional(details.Plan),
+			PreDeployCommand: types.StringNull(),
+		}
+
+		if details.EnvSpecificDetails != nil {
+			if envDetails, err := api.DecodeEnvSpecificDetails(*details.EnvSpecificDetails); err == nil {
+				service.BackgroundWorkerDetails.PreDeployCommand = fromStringOptionalNil(envDetails.PreDeployCommand)
+				service.BackgroundWorkerDetails.Native = fromNative(details.Env, envDetails)
+			}
+		}
+
+		if details.Disk != nil {
+			var prior *Disk
+
+			if s.BackgroundWorkerDetails != nil {
+				prior = s.BackgroundWorkerDetails.Disk
+			}
+
+			service.BackgroundWorkerDetails.Disk = fromDisk(details.Disk.Name, prior)
 		}
 	}
 
 	if serviceType == render.StaticSite {
-		details, _ := response.ServiceDetails.AsStaticSiteDetails()
+		details, _ := serviceDetails.AsStaticSiteDetails()
 
 		service.StaticSiteDetails = &StaticSiteDetails{
 			BuildCommand: fromStringOptional(details.BuildCommand),
 			PublishPath:  fromStringOptional(details.PublishPath),
 			Url:          fromStringOptional(details.Url),
 		}
+
+		if s.StaticSiteDetails != nil {
+			service.StaticSiteDetails.PullRequestPreviewsEnabled = fromManagedYesNo(details.PullRequestPreviewsEnabled, s.StaticSiteDetails.PullRequestPreviewsEnabled)
+		}
 	}
 
 	return service
 }
 
-func (s Service) ToServicePOST(ownerId string) (*render.ServicePOST, error) {
+func (s Service) ToServicePOST(ownerId string) (*api.ServicePOST, error) {
 	serviceType := render.ServiceType(s.Type.ValueString())
 
 	service := render.ServicePOST{
@@ -135,6 +249,11 @@ func (s Service) ToServicePOST(ownerId string) (*render.ServicePOST, error) {
 		OwnerId: ownerId,
 	}
 
+	if autoDeploy := yesNoOptional(s.AutoDeploy); autoDeploy != nil {
+		value := render.ServicePOSTAutoDeploy(*autoDeploy)
+		service.AutoDeploy = &value
+	}
+
 	serviceDetails := render.ServicePOST_ServiceDetails{}
 
 	if serviceType == render.WebService || s.WebServiceDetails != nil {
@@ -153,11 +272,11 @@ func (s Service) ToServicePOST(ownerId string) (*render.ServicePOST, error) {
 			return nil, err
 		}
 
-		if utils.Struct(mapped, &details) != nil {
+		if err := utils.Struct(mapped, &details); err != nil {
 			return nil, err
 		}
 
-		if serviceDetails.FromWebServiceDetailsPOST(details) != nil {
+		if err := serviceDetails.FromWebServiceDetailsPOST(details); err != nil {
 			return nil, err
 		}
 	}
@@ -178,11 +297,11 @@ func (s Service) ToServicePOST(ownerId string) (*render.ServicePOST, error) {
 			return nil, err
 		}
 
-		if utils.Struct(mapped, &details) != nil {
+		if err := utils.Struct(mapped, &details); err != nil {
 			return nil, err
 		}
 
-		if serviceDetails.FromStaticSiteDetailsPOST(details) != nil {
+		if err := serviceDetails.FromStaticSiteDetailsPOST(details); err != nil {
 			return nil, err
 		}
 	}
@@ -203,21 +322,65 @@ func (s Service) ToServicePOST(ownerId string) (*render.ServicePOST, error) {
 			return nil, err
 		}
 
-		if utils.Struct(mapped, &details) != nil {
+		if err := utils.Struct(mapped, &details); err != nil {
 			return nil, err
 		}
 
-		if serviceDetails.FromPrivateServiceDetailsPOST(details) != nil {
+		if err := serviceDetails.FromPrivateServiceDetailsPOST(details); err != nil {
 			return nil, err
 		}
 	}
 
+	if serviceType == render.BackgroundWorker || s.BackgroundWorkerDetails != nil {
+		if s.BackgroundWorkerDetails == nil {
+			return nil, fmt.Errorf("'background_worker_details' is required for services of type 'background_worker'")
+		}
+
+		if serviceType != render.BackgroundWorker {
+			return nil, fmt.Errorf("'background_worker_details' can only be used for services of type 'background_worker'")
+		}
+
+		details := render.BackgroundWorkerDetailsPOST{}
+		mapped, err := toBackgroundWorkerDetails(s.BackgroundWorkerDetails)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if err := utils.Struct(mapped, &details); err != nil {
+			return nil, err
+		}
+
+		if err := serviceDetails.FromBackgroundWorkerDetailsPOST(details); err != nil {
+			return nil, err
+		}
+	}
+
+	extra, err := s.extraServiceDetails()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := mergeUnion(&serviceDetails, extra); err != nil {
+		return nil, err
+	}
+
 	service.ServiceDetails = &serviceDetails
 
-	return &service, nil
+	post := api.ServicePOST{
+		ServicePOST: service,
+		RootDir:     stringOptionalNil(s.RootDirectory),
+	}
+
+	if s.BuildFilter != nil {
+		post.BuildFilter = toBuildFilter(s.BuildFilter)
+	}
+
+	return &post, nil
 }
 
-func (s Service) ToServicePATCH() (*render.ServicePATCH, error) {
+func (s Service) ToServicePATCH() (*api.ServicePATCH, error) {
 	serviceType := render.ServiceType(s.Type.ValueString())
 
 	service := render.ServicePATCH{
@@ -225,6 +388,11 @@ func (s Service) ToServicePATCH() (*render.ServicePATCH, error) {
 		Branch: stringOptionalNil(s.Branch),
 	}
 
+	if autoDeploy := yesNoOptional(s.AutoDeploy); autoDeploy != nil {
+		value := render.ServicePATCHAutoDeploy(*autoDeploy)
+		service.AutoDeploy = &value
+	}
+
 	serviceDetails := render.ServicePATCH_ServiceDetails{}
 
 	if serviceType == render.WebService || s.WebServiceDetails != nil {
@@ -243,11 +411,11 @@ func (s Service) ToServicePATCH() (*render.ServicePATCH, error) {
 			return nil, err
 		}
 
-		if utils.Struct(mapped, &details) != nil {
+		if err := utils.Struct(mapped, &details); err != nil {
 			return nil, err
 		}
 
-		if serviceDetails.FromWebServiceDetailsPATCH(details) != nil {
+		if err := serviceDetails.FromWebServiceDetailsPATCH(details); err != nil {
 			return nil, err
 		}
 	}
@@ -268,11 +436,11 @@ func (s Service) ToServicePATCH() (*render.ServicePATCH, error) {
 			return nil, err
 		}
 
-		if utils.Struct(mapped, &details) != nil {
+		if err := utils.Struct(mapped, &details); err != nil {
 			return nil, err
 		}
 
-		if serviceDetails.FromStaticSiteDetailsPATCH(details) != nil {
+		if err := serviceDetails.FromStaticSiteDetailsPATCH(details); err != nil {
 			return nil, err
 		}
 	}
@@ -293,18 +461,111 @@ func (s Service) ToServicePATCH() (*render.ServicePATCH, error) {
 			return nil, err
 		}
 
-		if utils.Struct(mapped, &details) != nil {
+		if err := utils.Struct(mapped, &details); err != nil {
+			return nil, err
+		}
+
+		if err := serviceDetails.FromPrivateServiceDetailsPATCH(details); err != nil {
+			return nil, err
+		}
+	}
+
+	if serviceType == render.BackgroundWorker || s.BackgroundWorkerDetails != nil {
+		if s.BackgroundWorkerDetails == nil {
+			return nil, fmt.Errorf("'background_worker_details' is required for services of type 'background_worker'")
+		}
+
+		if serviceType != render.BackgroundWorker {
+			return nil, fmt.Errorf("'background_worker_details' can only be used for services of type 'background_worker'")
+		}
+
+		details := render.BackgroundWorkerDetailsPATCH{}
+		mapped, err := toBackgroundWorkerDetails(s.BackgroundWorkerDetails)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if err := utils.Struct(mapped, &details); err != nil {
 			return nil, err
 		}
 
-		if serviceDetails.FromPrivateServiceDetailsPATCH(details) != nil {
+		if err := serviceDetails.FromBackgroundWorkerDetailsPATCH(details); err != nil {
 			return nil, err
 		}
 	}
 
+	extra, err := s.extraServiceDetails()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := mergeUnion(&serviceDetails, extra); err != nil {
+		return nil, err
+	}
+
 	service.ServiceDetails = &serviceDetails
 
-	return &service, nil
+	// Both are always sent, so removing them from the configuration clears them
+	rootDir := s.RootDirectory.ValueString()
+
+	patch := api.ServicePATCH{
+		ServicePATCH: service,
+		RootDir:      &rootDir,
+		BuildFilter:  toBuildFilter(s.BuildFilter),
+	}
+
+	return &patch, nil
+}
+
+func toBuildFilter(buildFilter *BuildFilter) *api.BuildFilter {
+	result := &api.BuildFilter{Paths: []string{}, IgnoredPaths: []string{}}
+
+	if buildFilter == nil {
+		return result
+	}
+
+	for _, path := range buildFilter.Paths {
+		result.Paths = append(result.Paths, path.ValueString())
+	}
+
+	for _, path := range buildFilter.IgnoredPaths {
+		result.IgnoredPaths = append(result.IgnoredPaths, path.ValueString())
+	}
+
+	return result
+}
+
+// fromBuildFilter reads a build filter back, treating an empty filter as unset unless prior has one.
+func fromBuildFilter(buildFilter *api.BuildFilter, prior *BuildFilter) *BuildFilter {
+	if buildFilter == nil || (prior == nil && len(buildFilter.Paths) == 0 && len(buildFilter.IgnoredPaths) == 0) {
+		return nil
+	}
+
+	if prior == nil {
+		prior = &BuildFilter{}
+	}
+
+	return &BuildFilter{
+		Paths:        fromStrings(buildFilter.Paths, prior.Paths),
+		IgnoredPaths: fromStrings(buildFilter.IgnoredPaths, prior.IgnoredPaths),
+	}
+}
+
+// fromStrings returns null for an empty list, unless prior is an empty list rather than null.
+func fromStrings(values []string, prior []types.String) []types.String {
+	if len(values) == 0 && prior == nil {
+		return nil
+	}
+
+	result := make([]types.String, len(values))
+
+	for i, value := range values {
+		result[i] = types.StringValue(value)
+	}
+
+	return result
 }
 
 func toWebServiceDetails(webServiceDetails *WebServiceDetails) (map[string]interface{}, error) {
@@ -313,17 +574,20 @@ func toWebServiceDetails(webServiceDetails *WebServiceDetails) (map[string]inter
 		"env":             stringOptional(webServiceDetails.Env),
 		"plan":            stringOptionalNil(webServiceDetails.Plan),
 		"healthCheckPath": stringOptional(webServiceDetails.HealthCheckPath),
+
+		"pullRequestPreviewsEnabled": yesNoOptional(webServiceDetails.PullRequestPreviewsEnabled),
 	}
 
-	if webServiceDetails.Native != nil {
-		native := map[string]interface{}{
-			"buildCommand": webServiceDetails.Native.BuildCommand.ValueString(),
-			"startCommand": webServiceDetails.Native.StartCommand.ValueString(),
-		}
+	envSpecificDetails := toEnvSpecificDetails(webServiceDetails.Native, webServiceDetails.PreDeployCommand)
 
-		details["envSpecificDetails"] = native
+	if webServiceDetails.Env.ValueString() == string(render.Docker) {
+		envSpecificDetails["dockerCommand"] = webServiceDetails.DockerCommand.ValueString()
+	} else if !webServiceDetails.DockerCommand.IsNull() {
+		return nil, fmt.Errorf("'docker_command' can only be used for services with env 'docker'")
 	}
 
+	details["envSpecificDetails"] = envSpecificDetails
+
 	return details, nil
 }
 
@@ -332,6 +596,26 @@ func toPrivateServiceDetails(serviceDetails *PrivateServiceDetails) (map[string]
 		"region": stringOptionalNil(serviceDetails.Region),
 		"env":    stringOptional(serviceDetails.Env),
 		"plan":   stringOptionalNil(serviceDetails.Plan),
+
+		"pullRequestPreviewsEnabled": yesNoOptional(serviceDetails.PullRequestPreviewsEnabled),
+	}
+
+	details["envSpecificDetails"] = toEnvSpecificDetails(nil, serviceDetails.PreDeployCommand)
+
+	if serviceDetails.Disk != nil {
+		details["disk"] = toDisk(serviceDetails.Disk)
+	}
+
+	return details, nil
+}
+
+func toBackgroundWorkerDetails(serviceDetails *BackgroundWorkerDetails) (map[string]interface{}, error) {
+	details := map[string]interface{}{
+		"region": stringOptionalNil(serviceDetails.Region),
+		"env":    stringOptional(serviceDetails.Env),
+		"plan":   stringOptionalNil(serviceDetails.Plan),
+
+		"envSpecificDetails": toEnvSpecificDetails(serviceDetails.Native, serviceDetails.PreDeployCommand),
 	}
 
 	if serviceDetails.Disk != nil {
@@ -341,15 +625,151 @@ func toPrivateServiceDetails(serviceDetails *PrivateServiceDetails) (map[string]
 	return details, nil
 }
 
+// extraServiceDetails returns the serviceDetails fields render-go doesn't encode yet.
+func (s Service) extraServiceDetails() (map[string]interface{}, error) {
+	extra := map[string]interface{}{}
+
+	if s.WebServiceDetails != nil {
+		if value := int64Optional(s.WebServiceDetails.MaxShutdownDelaySeconds); value != nil {
+			extra["maxShutdownDelaySeconds"] = *value
+		}
+	}
+
+	if s.Previews != nil {
+		switch render.ServiceType(s.Type.ValueString()) {
+		case render.WebService, render.PrivateService, render.StaticSite:
+		default:
+			return nil, fmt.Errorf("'previews' can only be used for services of type 'web_service', 'private_service' or 'static_site'")
+		}
+
+		// Unset values are sent as null, so removing them from the configuration clears them
+		extra["previews"] = map[string]interface{}{
+			"generation":      stringOptional(s.Previews.Generation),
+			"expireAfterDays": int64Optional(s.Previews.ExpireAfterDays),
+			"plan":            stringOptionalNil(s.Previews.Plan),
+		}
+	}
+
+	return extra, nil
+}
+
+// fromPreviews reads previews back, where a service without previews never generates them.
+func fromPreviews(previews *api.Previews) *Previews {
+	result := &Previews{
+		Generation:      types.StringValue("off"),
+		ExpireAfterDays: types.Int64Null(),
+		Plan:            types.StringNull(),
+	}
+
+	if previews == nil {
+		return result
+	}
+
+	if previews.Generation != nil {
+		result.Generation = types.StringValue(*previews.Generation)
+	}
+
+	result.ExpireAfterDays = fromIntOptional(previews.ExpireAfterDays)
+	result.Plan = fromStringOptionalNil(previews.Plan)
+
+	return result
+}
+
+type jsonUnion interface {
+	json.Marshaler
+	json.Unmarshaler
+}
+
+// mergeUnion adds values to one of the serviceDetails unions of render-go.
+func mergeUnion(union jsonUnion, values map[string]interface{}) error {
+	if len(values) == 0 {
+		return nil
+	}
+
+	raw, err := union.MarshalJSON()
+
+	if err != nil {
+		return err
+	}
+
+	var merged map[string]interface{}
+
+	if err := json.Unmarshal(raw, &merged); err != nil {
+		return err
+	}
+
+	if merged == nil {
+		merged = map[string]interface{}{}
+	}
+
+	for key, value := range values {
+		merged[key] = value
+	}
+
+	if raw, err = json.Marshal(merged); err != nil {
+		return err
+	}
+
+	return union.UnmarshalJSON(raw)
+}
+
+// toEnvSpecificDetails always sends the pre-deploy command, so removing it from the configuration clears it.
+func toEnvSpecificDetails(native *WebServiceDetailsNative, preDeployCommand types.String) map[string]interface{} {
+	details := map[string]interface{}{
+		"preDeployCommand": preDeployCommand.ValueString(),
+	}
+
+	if native != nil {
+		details["buildCommand"] = native.BuildCommand.ValueString()
+		details["startCommand"] = native.StartCommand.ValueString()
+	}
+
+	return details
+}
+
+// fromNative reads the build and start commands of services that don't run on docker.
+func fromNative(env *render.ServiceEnv, details api.EnvSpecificDetails) *WebServiceDetailsNative {
+	if env != nil && *env == render.Docker {
+		return nil
+	}
+
+	if details.BuildCommand == nil && details.StartCommand == nil {
+		return nil
+	}
+
+	return &WebServiceDetailsNative{
+		BuildCommand: fromStringOptional(details.BuildCommand),
+		StartCommand: fromStringOptional(details.StartCommand),
+	}
+}
+
 func toStaticSiteDetails(staticSiteDetails *StaticSiteDetails) (map[string]interface{}, error) {
 	details := map[string]interface{}{
 		"buildCommand": staticSiteDetails.BuildCommand.ValueString(),
 		"publishPath":  staticSiteDetails.PublishPath.ValueString(),
+
+		"pullRequestPreviewsEnabled": yesNoOptional(staticSiteDetails.PullRequestPreviewsEnabled),
 	}
 
 	return details, nil
 }
 
+// fromDisk reads a disk back. Render only returns its name, so the rest is kept from prior.
+func fromDisk(name *string, prior *Disk) *Disk {
+	disk := &Disk{
+		Name:      fromStringOptional(name),
+		MountPath: types.StringNull(),
+		SizeGB:    types.Int64Null(),
+	}
+
+	if prior != nil {
+		disk.MountPath = prior.MountPath
+		disk.SizeGB = prior.SizeGB
+	}
+
+	return disk
+}
+
 func toDisk(d *Disk) map[string]interface{} {
 	disk := map[string]interface{}{
 		"name":      d.Name.ValueString(),
@@ -380,8 +800,33 @@ func stringOptionalNil(str types.String) *string {
 	return &value
 }
 
+func yesNoOptional(b types.Bool) *utils.YesNo {
+	if b.IsNull() || b.IsUnknown() {
+		return nil
+	}
+
+	return utils.ToYesNo(b.ValueBool())
+}
+
+func fromYesNo[T ~string](value *T) types.Bool {
+	if value == nil {
+		return types.BoolNull()
+	}
+
+	return types.BoolValue(*value == T(utils.Yes))
+}
+
+// fromManagedYesNo only reads a yes/no value back when it is managed, i.e. set in prior.
+func fromManagedYesNo[T ~string](value *T, prior types.Bool) types.Bool {
+	if prior.IsNull() || value == nil {
+		return prior
+	}
+
+	return fromYesNo(value)
+}
+
 func int64Optional(num types.Int64) *int64 {
-	if num.IsNull() {
+	if num.IsNull() || num.IsUnknown() {
 		return nil
 	}
 
@@ -406,6 +851,15 @@ func fromStringOptional(str *string) types.String {
 	return types.StringValue(*str)
 }
 
+// fromStringOptionalNil is the inverse of stringOptionalNil, reading an empty string as null.
+func fromStringOptionalNil(str *string) types.String {
+	if str == nil || *str == "" {
+		return types.StringNull()
+	}
+
+	return types.StringValue(*str)
+}
+
 func fromServiceType(t *render.ServiceType) types.String {
 	if t == nil {
 		return types.StringNull()
@@ -1,18 +1,96 @@
 package models
 
 import (
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/jackall3n/render-go"
 )
 
+// apexIP is the load balancer address Render asks apex domains to point at.
+const apexIP = "216.24.57.1"
+
 type ServiceCustomDomain struct {
-	ServiceID  types.String `tfsdk:"service_id"`
-	DomainName types.String `tfsdk:"domain_name"`
+	ID                  types.String `tfsdk:"id"`
+	ServiceID           types.String `tfsdk:"service_id"`
+	DomainName          types.String `tfsdk:"domain_name"`
+	DomainType          types.String `tfsdk:"domain_type"`
+	VerificationStatus  types.String `tfsdk:"verification_status"`
+	RedirectForName     types.String `tfsdk:"redirect_for_name"`
+	PublicSuffix        types.String `tfsdk:"public_suffix"`
+	DNSRecords          types.List   `tfsdk:"dns_records"`
+	WaitForVerification types.Bool   `tfsdk:"wait_for_verification"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+var DNSRecordAttributeTypes = map[string]attr.Type{
+	"type":  types.StringType,
+	"name":  types.StringType,
+	"value": types.StringType,
 }
 
-func (s ServiceCustomDomain) FromResponse(response render.CustomDomain) ServiceCustomDomain {
-	return ServiceCustomDomain{
-		ServiceID:  s.ServiceID, //TODO: pass service ID from response
-		DomainName: fromStringOptional(response.Name),
+// FromResponse maps a custom domain onto state. host is the `onrender.com` host
+// name of the service, which subdomains have to CNAME to.
+func (s ServiceCustomDomain) FromResponse(response render.CustomDomain, host string) ServiceCustomDomain {
+	domain := ServiceCustomDomain{
+		ID:                  fromStringOptional(response.Id),
+		ServiceID:           s.ServiceID,
+		DomainName:          fromStringOptional(response.Name),
+		DomainType:          types.StringNull(),
+		VerificationStatus:  types.StringNull(),
+		RedirectForName:     fromStringOptional(response.RedirectForName),
+		PublicSuffix:        fromStringOptional(response.PublicSuffix),
+		WaitForVerification: s.WaitForVerification,
+		Timeouts:            s.Timeouts,
 	}
+
+	if response.Server != nil && response.Server.Id != nil {
+		domain.ServiceID = types.StringValue(*response.Server.Id)
+	}
+
+	if response.DomainType != nil {
+		domain.DomainType = types.StringValue(string(*response.DomainType))
+	}
+
+	if response.VerificationStatus != nil {
+		domain.VerificationStatus = types.StringValue(string(*response.VerificationStatus))
+	}
+
+	domain.DNSRecords = toDNSRecords(response, host)
+
+	return domain
+}
+
+func (s ServiceCustomDomain) IsVerified() bool {
+	return s.VerificationStatus.ValueString() == string(render.CustomDomainVerificationStatusVerified)
+}
+
+func toDNSRecords(response render.CustomDomain, host string) types.List {
+	objectType := types.ObjectType{AttrTypes: DNSRecordAttributeTypes}
+
+	if response.Name == nil || response.DomainType == nil {
+		return types.ListNull(objectType)
+	}
+
+	record := map[string]attr.Value{
+		"name": types.StringValue(*response.Name),
+	}
+
+	if *response.DomainType == render.CustomDomainDomainTypeApex {
+		record["type"] = types.StringValue("A")
+		record["value"] = types.StringValue(apexIP)
+	} else {
+		record["type"] = types.StringValue("CNAME")
+		record["value"] = types.StringNull()
+
+		if host != "" {
+			record["value"] = types.StringValue(host)
+		}
+	}
+
+	value, _ := types.ObjectValue(DNSRecordAttributeTypes, record)
+	list, _ := types.ListValue(objectType, []attr.Value{value})
+
+	return list
 }
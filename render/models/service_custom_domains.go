@@ -0,0 +1,50 @@
+package models
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jackall3n/render-go"
+)
+
+type ServiceCustomDomains struct {
+	ServiceID types.String               `tfsdk:"service_id"`
+	Domains   []ServiceCustomDomainsItem `tfsdk:"domains"`
+}
+
+type ServiceCustomDomainsItem struct {
+	ID                 types.String `tfsdk:"id"`
+	DomainName         types.String `tfsdk:"domain_name"`
+	DomainType         types.String `tfsdk:"domain_type"`
+	VerificationStatus types.String `tfsdk:"verification_status"`
+	RedirectForName    types.String `tfsdk:"redirect_for_name"`
+	PublicSuffix       types.String `tfsdk:"public_suffix"`
+}
+
+func (s ServiceCustomDomains) FromResponse(domains []render.CustomDomain) ServiceCustomDomains {
+	result := ServiceCustomDomains{
+		ServiceID: s.ServiceID,
+		Domains:   []ServiceCustomDomainsItem{},
+	}
+
+	for _, domain := range domains {
+		item := ServiceCustomDomainsItem{
+			ID:                 fromStringOptional(domain.Id),
+			DomainName:         fromStringOptional(domain.Name),
+			DomainType:         types.StringNull(),
+			VerificationStatus: types.StringNull(),
+			RedirectForName:    fromStringOptional(domain.RedirectForName),
+			PublicSuffix:       fromStringOptional(domain.PublicSuffix),
+		}
+
+		if domain.DomainType != nil {
+			item.DomainType = types.StringValue(string(*domain.DomainType))
+		}
+
+		if domain.VerificationStatus != nil {
+			item.VerificationStatus = types.StringValue(string(*domain.VerificationStatus))
+		}
+
+		result.Domains = append(result.Domains, item)
+	}
+
+	return result
+}
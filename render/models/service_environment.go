@@ -1,42 +1,217 @@
 package models
 
 import (
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/jackall3n/render-go"
 )
 
 type ServiceEnvironment struct {
-	Service   types.String                 `tfsdk:"service"`
-	Variables []ServiceEnvironmentVariable `tfsdk:"variables"`
+	Service            types.String                          `tfsdk:"service"`
+	Variables          map[string]ServiceEnvironmentVariable `tfsdk:"variables"`
+	SensitiveVariables map[string]types.String               `tfsdk:"sensitive_variables"`
+	GeneratedValues    types.Map                             `tfsdk:"generated_values"`
+	RetainOnDestroy    types.Bool                            `tfsdk:"retain_on_destroy"`
+	Timeouts           timeouts.Value                        `tfsdk:"timeouts"`
 }
 
 type ServiceEnvironmentVariable struct {
+	Value     types.String `tfsdk:"value"`
+	Generated types.Bool   `tfsdk:"generated"`
+}
+
+// ServiceEnvironmentV0 is the schema version 0 state, where variables were a list.
+type ServiceEnvironmentV0 struct {
+	Service            types.String                   `tfsdk:"service"`
+	Variables          []ServiceEnvironmentVariableV0 `tfsdk:"variables"`
+	SensitiveVariables map[string]types.String        `tfsdk:"sensitive_variables"`
+	GeneratedValues    types.Map                      `tfsdk:"generated_values"`
+}
+
+type ServiceEnvironmentVariableV0 struct {
 	Key       types.String `tfsdk:"key"`
 	Value     types.String `tfsdk:"value"`
 	Generated types.Bool   `tfsdk:"generated"`
 }
 
-func (s ServiceEnvironment) FromResponse(variables []ServiceEnvironmentVariable) ServiceEnvironment {
-	return ServiceEnvironment{
-		Service:   s.Service,
-		Variables: variables,
+// Upgrade converts version 0 state to the map keyed by variable name. When a key
+// was listed more than once, the last item wins, as it did when sent to Render.
+func (s ServiceEnvironmentV0) Upgrade() ServiceEnvironment {
+	result := ServiceEnvironment{
+		Service:            s.Service,
+		Variables:          map[string]ServiceEnvironmentVariable{},
+		SensitiveVariables: s.SensitiveVariables,
+		GeneratedValues:    s.GeneratedValues,
+		RetainOnDestroy:    types.BoolNull(),
+		Timeouts:           NullTimeouts(),
+	}
+
+	for _, v := range s.Variables {
+		result.Variables[v.Key.ValueString()] = ServiceEnvironmentVariable{
+			Value:     v.Value,
+			Generated: v.Generated,
+		}
+	}
+
+	if result.GeneratedValues.IsNull() || result.GeneratedValues.IsUnknown() {
+		result.GeneratedValues = types.MapValueMust(types.StringType, map[string]attr.Value{})
+	}
+
+	return result
+}
+
+// FromResponse maps the variables of a service onto state. Variables listed in
+// `sensitive_variables` are never read back, their state value is kept for as
+// long as the key still exists. Generated variables keep a null `value`, what
+// Render generated is stored in `generated_values`.
+func (s ServiceEnvironment) FromResponse(envVars []render.EnvVar) ServiceEnvironment {
+	result := ServiceEnvironment{
+		Service:         s.Service,
+		Variables:       map[string]ServiceEnvironmentVariable{},
+		RetainOnDestroy: s.RetainOnDestroy,
+		Timeouts:        s.Timeouts,
+	}
+
+	if s.SensitiveVariables != nil {
+		result.SensitiveVariables = map[string]types.String{}
+	}
+
+	generated := s.GeneratedKeys()
+
+	for _, envVar := range envVars {
+		if value, ok := s.SensitiveVariables[envVar.Key]; ok {
+			result.SensitiveVariables[envVar.Key] = value
+			continue
+		}
+
+		if generated[envVar.Key] {
+			result.Variables[envVar.Key] = ServiceEnvironmentVariable{
+				Value:     types.StringNull(),
+				Generated: types.BoolValue(true),
+			}
+			continue
+		}
+
+		result.Variables[envVar.Key] = ServiceEnvironmentVariable{}.FromResponse(envVar)
+	}
+
+	return result.WithGeneratedValues(envVars)
+}
+
+// WithGeneratedValues sets `generated_values` from the variables Render returned.
+func (s ServiceEnvironment) WithGeneratedValues(envVars []render.EnvVar) ServiceEnvironment {
+	generated := s.GeneratedKeys()
+	values := map[string]attr.Value{}
+
+	for _, envVar := range envVars {
+		if generated[envVar.Key] {
+			values[envVar.Key] = types.StringValue(envVar.Value)
+		}
+	}
+
+	s.GeneratedValues, _ = types.MapValue(types.StringType, values)
+
+	return s
+}
+
+// GeneratedKeys returns the keys of the variables with `generated = true`.
+func (s ServiceEnvironment) GeneratedKeys() map[string]bool {
+	keys := map[string]bool{}
+
+	for key, v := range s.Variables {
+		if v.Generated.ValueBool() {
+			keys[key] = true
+		}
+	}
+
+	return keys
+}
+
+// ToEnvVarsPATCH builds the full set of variables of the service, sorted by key.
+// current holds the value Render already has for variables that must be sent
+// unchanged: sensitive variables which were not changed in the configuration
+// and generated variables which already exist, so they are not generated again.
+func (s ServiceEnvironment) ToEnvVarsPATCH(current map[string]string) (render.EnvVarsPATCH, error) {
+	variables := render.EnvVarsPATCH{}
+
+	for _, key := range sortedKeys(s.Variables) {
+		v := s.Variables[key]
+
+		if existing, ok := current[key]; ok && v.Generated.ValueBool() {
+			v = ServiceEnvironmentVariable{Value: types.StringValue(existing)}
+		}
+
+		item, err := v.ToEnvVarsItemPATCH(key)
+
+		if err != nil {
+			return nil, err
+		}
+
+		variables = append(variables, *item)
 	}
+
+	for _, key := range sortedKeys(s.SensitiveVariables) {
+		value := s.SensitiveVariables[key]
+
+		if existing, ok := current[key]; ok {
+			value = types.StringValue(existing)
+		}
+
+		item, err := ServiceEnvironmentVariable{Value: value}.ToEnvVarsItemPATCH(key)
+
+		if err != nil {
+			return nil, err
+		}
+
+		variables = append(variables, *item)
+	}
+
+	return variables, nil
+}
+
+// ToEnvVarsPATCHWithout returns envVars without the variables managed by this
+// resource, so they can be removed while leaving every other variable in place.
+func (s ServiceEnvironment) ToEnvVarsPATCHWithout(envVars []render.EnvVar) (render.EnvVarsPATCH, error) {
+	variables := render.EnvVarsPATCH{}
+
+	for _, envVar := range envVars {
+		if _, ok := s.Variables[envVar.Key]; ok {
+			continue
+		}
+
+		if _, ok := s.SensitiveVariables[envVar.Key]; ok {
+			continue
+		}
+
+		item, err := ServiceEnvironmentVariable{Value: types.StringValue(envVar.Value)}.ToEnvVarsItemPATCH(envVar.Key)
+
+		if err != nil {
+			return nil, err
+		}
+
+		variables = append(variables, *item)
+	}
+
+	return variables, nil
 }
 
 func (v ServiceEnvironmentVariable) FromResponse(response render.EnvVar) ServiceEnvironmentVariable {
 	return ServiceEnvironmentVariable{
-		Key:   types.StringValue(response.Key),
-		Value: fromStringOptional(&response.Value),
+		Value:     fromStringOptional(&response.Value),
+		Generated: types.BoolNull(),
 	}
 }
 
-func (v ServiceEnvironmentVariable) ToEnvVarsItemPATCH() (*render.EnvVarsPATCH_Item, error) {
+func (v ServiceEnvironmentVariable) ToEnvVarsItemPATCH(key string) (*render.EnvVarsPATCH_Item, error) {
 	item := render.EnvVarsPATCH_Item{}
 
 	if v.Generated.ValueBool() {
 		err := item.FromEnvVarKeyGenerateValue(render.EnvVarKeyGenerateValue{
-			Key:           v.Key.ValueString(),
-			GenerateValue: "true",
+			Key:           key,
+			GenerateValue: render.EnvVarKeyGenerateValueGenerateValueYes,
 		})
 
 		if err != nil {
@@ -47,7 +222,7 @@ func (v ServiceEnvironmentVariable) ToEnvVarsItemPATCH() (*render.EnvVarsPATCH_I
 	}
 
 	err := item.FromEnvVarKeyValue(render.EnvVarKeyValue{
-		Key:   v.Key.ValueString(),
+		Key:   key,
 		Value: v.Value.ValueString(),
 	})
 
@@ -57,3 +232,15 @@ func (v ServiceEnvironmentVariable) ToEnvVarsItemPATCH() (*render.EnvVarsPATCH_I
 
 	return &item, nil
 }
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
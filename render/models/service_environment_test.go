@@ -0,0 +1,195 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jackall3n/render-go"
+)
+
+func TestServiceEnvironmentFromResponseKeepsGeneratedValueOutOfVariables(t *testing.T) {
+	state := ServiceEnvironment{
+		Service: types.StringValue("srv-1"),
+		Variables: map[string]ServiceEnvironmentVariable{
+			"PLAIN":  {Value: types.StringValue("a")},
+			"SECRET": {Value: types.StringNull(), Generated: types.BoolValue(true)},
+		},
+	}
+
+	result := state.FromResponse([]render.EnvVar{
+		{Key: "SECRET", Value: "generated-by-render"},
+		{Key: "PLAIN", Value: "a"},
+	})
+
+	if len(result.Variables) != 2 {
+		t.Fatalf("expected 2 variables, got %d", len(result.Variables))
+	}
+
+	secret := result.Variables["SECRET"]
+
+	if !secret.Value.IsNull() {
+		t.Errorf("expected generated variable value to be null, got %s", secret.Value)
+	}
+
+	if !secret.Generated.ValueBool() {
+		t.Errorf("expected generated variable to keep generated = true")
+	}
+
+	values := result.GeneratedValues.Elements()
+
+	if len(values) != 1 {
+		t.Fatalf("expected 1 generated value, got %d", len(values))
+	}
+
+	if values["SECRET"] != types.StringValue("generated-by-render") {
+		t.Errorf("unexpected generated value %s", values["SECRET"])
+	}
+}
+
+func TestServiceEnvironmentFromResponseMatchesPlan(t *testing.T) {
+	plan := ServiceEnvironment{
+		Service: types.StringValue("srv-1"),
+		Variables: map[string]ServiceEnvironmentVariable{
+			"A":      {Value: types.StringValue("1"), Generated: types.BoolNull()},
+			"B":      {Value: types.StringValue("2"), Generated: types.BoolNull()},
+			"SECRET": {Value: types.StringNull(), Generated: types.BoolValue(true)},
+		},
+	}
+
+	envVars := []render.EnvVar{{Key: "SECRET", Value: "x"}, {Key: "B", Value: "2"}, {Key: "A", Value: "1"}}
+
+	state := plan.WithGeneratedValues(envVars)
+	read := state.FromResponse(envVars)
+
+	for key, v := range plan.Variables {
+		if read.Variables[key] != v {
+			t.Errorf("expected read variable %s %v to equal the planned one %v", key, read.Variables[key], v)
+		}
+	}
+
+	if !read.GeneratedValues.Equal(state.GeneratedValues) {
+		t.Errorf("expected generated values to be stable, got %s and %s", read.GeneratedValues, state.GeneratedValues)
+	}
+}
+
+func TestServiceEnvironmentFromResponseKeepsSensitiveValues(t *testing.T) {
+	state := ServiceEnvironment{
+		Service:            types.StringValue("srv-1"),
+		SensitiveVariables: map[string]types.String{"PASSWORD": types.StringValue("from-config"), "GONE": types.StringValue("x")},
+	}
+
+	result := state.FromResponse([]render.EnvVar{{Key: "PASSWORD", Value: "rotated"}})
+
+	if len(result.Variables) != 0 {
+		t.Errorf("expected sensitive variables not to be read into variables, got %v", result.Variables)
+	}
+
+	if result.SensitiveVariables["PASSWORD"] != types.StringValue("from-config") {
+		t.Errorf("expected the state value to be kept, got %s", result.SensitiveVariables["PASSWORD"])
+	}
+
+	if _, ok := result.SensitiveVariables["GONE"]; ok {
+		t.Errorf("expected variables removed outside of terraform to be dropped")
+	}
+}
+
+func TestServiceEnvironmentToEnvVarsPATCH(t *testing.T) {
+	plan := ServiceEnvironment{
+		Variables: map[string]ServiceEnvironmentVariable{
+			"PLAIN":    {Value: types.StringValue("a")},
+			"NEW":      {Value: types.StringNull(), Generated: types.BoolValue(true)},
+			"EXISTING": {Value: types.StringNull(), Generated: types.BoolValue(true)},
+		},
+		SensitiveVariables: map[string]types.String{
+			"KEPT":    types.StringValue("old"),
+			"CHANGED": types.StringValue("new"),
+		},
+	}
+
+	items, err := plan.ToEnvVarsPATCH(map[string]string{
+		"EXISTING": "generated-before",
+		"KEPT":     "rotated",
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []map[string]interface{}
+
+	for _, item := range items {
+		b, err := json.Marshal(item)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var m map[string]interface{}
+
+		if err := json.Unmarshal(b, &m); err != nil {
+			t.Fatal(err)
+		}
+
+		got = append(got, m)
+	}
+
+	expected := []map[string]interface{}{
+		{"key": "EXISTING", "value": "generated-before"},
+		{"key": "NEW", "generateValue": "yes"},
+		{"key": "PLAIN", "value": "a"},
+		{"key": "CHANGED", "value": "new"},
+		{"key": "KEPT", "value": "rotated"},
+	}
+
+	if len(got) != len(expected) {
+		t.Fatalf("expected %d items, got %d: %v", len(expected), len(got), got)
+	}
+
+	for i, e := range expected {
+		if len(got[i]) != len(e) {
+			t.Errorf("item %d: expected %v, got %v", i, e, got[i])
+			continue
+		}
+
+		for field, value := range e {
+			if got[i][field] != value {
+				t.Errorf("item %d: expected %v, got %v", i, e, got[i])
+			}
+		}
+	}
+}
+
+func TestServiceEnvironmentV0Upgrade(t *testing.T) {
+	prior := ServiceEnvironmentV0{
+		Service: types.StringValue("srv-1"),
+		Variables: []ServiceEnvironmentVariableV0{
+			{Key: types.StringValue("A"), Value: types.StringValue("1"), Generated: types.BoolNull()},
+			{Key: types.StringValue("SECRET"), Value: types.StringNull(), Generated: types.BoolValue(true)},
+			{Key: types.StringValue("A"), Value: types.StringValue("2"), Generated: types.BoolNull()},
+		},
+		GeneratedValues: types.MapNull(types.StringType),
+	}
+
+	result := prior.Upgrade()
+
+	if result.Service != prior.Service {
+		t.Errorf("expected service to be kept, got %s", result.Service)
+	}
+
+	if len(result.Variables) != 2 {
+		t.Fatalf("expected 2 variables, got %v", result.Variables)
+	}
+
+	if result.Variables["A"].Value != types.StringValue("2") {
+		t.Errorf("expected the last duplicate to win, got %s", result.Variables["A"].Value)
+	}
+
+	if !result.Variables["SECRET"].Generated.ValueBool() {
+		t.Errorf("expected SECRET to stay generated")
+	}
+
+	if result.GeneratedValues.IsNull() {
+		t.Errorf("expected generated values to be known after the upgrade")
+	}
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jackall3n/render-go"
+)
+
+type ServiceHeaders struct {
+	ServiceID types.String    `tfsdk:"service_id"`
+	Headers   []ServiceHeader `tfsdk:"headers"`
+}
+
+type ServiceHeader struct {
+	Path  types.String `tfsdk:"path"`
+	Name  types.String `tfsdk:"name"`
+	Value types.String `tfsdk:"value"`
+}
+
+func (s ServiceHeaders) FromResponse(headers []render.Header) ServiceHeaders {
+	result := ServiceHeaders{
+		ServiceID: s.ServiceID,
+		Headers:   []ServiceHeader{},
+	}
+
+	for _, header := range headers {
+		result.Headers = append(result.Headers, ServiceHeader{
+			Path:  types.StringValue(header.Path),
+			Name:  types.StringValue(header.Name),
+			Value: types.StringValue(header.Value),
+		})
+	}
+
+	return result
+}
+
+func (s ServiceHeaders) ToHeaders() []render.Header {
+	headers := []render.Header{}
+
+	for _, header := range s.Headers {
+		headers = append(headers, render.Header{
+			Path:  header.Path.ValueString(),
+			Name:  header.Name.ValueString(),
+			Value: header.Value.ValueString(),
+		})
+	}
+
+	return headers
+}
@@ -0,0 +1,16 @@
+package models
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type ServiceRestart struct {
+	ID         types.String `tfsdk:"id"`
+	ServiceID  types.String `tfsdk:"service_id"`
+	Triggers   types.Map    `tfsdk:"triggers"`
+	ClearCache types.Bool   `tfsdk:"clear_cache"`
+	DeployID   types.String `tfsdk:"deploy_id"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jackall3n/render-go"
+)
+
+type ServiceRollback struct {
+	ID        types.String `tfsdk:"id"`
+	ServiceID types.String `tfsdk:"service_id"`
+	DeployID  types.String `tfsdk:"deploy_id"`
+	Status    types.String `tfsdk:"status"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// FromResponse maps the deploy started by the rollback onto state.
+func (s ServiceRollback) FromResponse(response render.Deploy) ServiceRollback {
+	result := ServiceRollback{
+		ID:        types.StringValue(response.Id),
+		ServiceID: s.ServiceID,
+		DeployID:  s.DeployID,
+		Status:    types.StringNull(),
+		Timeouts:  s.Timeouts,
+	}
+
+	if response.Status != nil {
+		result.Status = types.StringValue(string(*response.Status))
+	}
+
+	return result
+}
@@ -0,0 +1,652 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jackall3n/render-go"
+	"github.com/jackall3n/terraform-provider-render/internal/fakerender"
+	"github.com/jackall3n/terraform-provider-render/render/api"
+)
+
+func webService() Service {
+	return Service{
+		ID:         types.StringUnknown(),
+		Name:       types.StringValue("api"),
+		Type:       types.StringValue("web_service"),
+		Repo:       types.StringValue("https://github.com/render-examples/express-hello-world"),
+		Branch:     types.StringUnknown(),
+		Owner:      types.StringUnknown(),
+		AutoDeploy: types.BoolValue(false),
+		WebServiceDetails: &WebServiceDetails{
+			Env:                        types.StringValue("node"),
+			Region:                     types.StringValue("frankfurt"),
+			Plan:                       types.StringUnknown(),
+			PullRequestPreviewsEnabled: types.BoolValue(true),
+			HealthCheckPath:            types.StringValue("/health"),
+			PreDeployCommand:           types.StringValue("npm run migrate"),
+			MaxShutdownDelaySeconds:    types.Int64Value(60),
+			DockerCommand:              types.StringNull(),
+			Url:                        types.StringUnknown(),
+			Native: &WebServiceDetailsNative{
+				BuildCommand: types.StringValue("npm install"),
+				StartCommand: types.StringValue("node server.js"),
+			},
+		},
+	}
+}
+
+func dockerWebService() Service {
+	return Service{
+		ID:         types.StringUnknown(),
+		Name:       types.StringValue("docker"),
+		Type:       types.StringValue("web_service"),
+		Repo:       types.StringValue("https://github.com/render-examples/docker"),
+		Branch:     types.StringValue("develop"),
+		Owner:      types.StringUnknown(),
+		AutoDeploy: types.BoolUnknown(),
+		WebServiceDetails: &WebServiceDetails{
+			Env:                        types.StringValue("docker"),
+			Region:                     types.StringUnknown(),
+			Plan:                       types.StringValue("standard"),
+			PullRequestPreviewsEnabled: types.BoolNull(),
+			HealthCheckPath:            types.StringUnknown(),
+			PreDeployCommand:           types.StringNull(),
+			MaxShutdownDelaySeconds:    types.Int64Unknown(),
+			DockerCommand:              types.StringValue("./bin/server --port 10000"),
+			Url:                        types.StringUnknown(),
+		},
+	}
+}
+
+func privateService() Service {
+	return Service{
+		ID:         types.StringUnknown(),
+		Name:       types.StringValue("db"),
+		Type:       types.StringValue("private_service"),
+		Repo:       types.StringValue("https://github.com/render-examples/mongodb"),
+		Branch:     types.StringUnknown(),
+		Owner:      types.StringUnknown(),
+		AutoDeploy: types.BoolValue(true),
+		PrivateServiceDetails: &PrivateServiceDetails{
+			Env:                        types.StringValue("docker"),
+			Region:                     types.StringUnknown(),
+			Plan:                       types.StringUnknown(),
+			PullRequestPreviewsEnabled: types.BoolValue(false),
+			PreDeployCommand:           types.StringNull(),
+			Url:                        types.StringUnknown(),
+			Disk: &Disk{
+				Name:      types.StringValue("db"),
+				MountPath: types.StringValue("/data/db"),
+				SizeGB:    types.Int64Value(10),
+			},
+		},
+	}
+}
+
+func backgroundWorker() Service {
+	return Service{
+		ID:         types.StringUnknown(),
+		Name:       types.StringValue("worker"),
+		Type:       types.StringValue("background_worker"),
+		Repo:       types.StringValue("https://github.com/render-examples/celery"),
+		Branch:     types.StringUnknown(),
+		Owner:      types.StringUnknown(),
+		AutoDeploy: types.BoolUnknown(),
+		BackgroundWorkerDetails: &BackgroundWorkerDetails{
+			Env:              types.StringValue("python"),
+			Region:           types.StringValue("ohio"),
+			Plan:             types.StringUnknown(),
+			PreDeployCommand: types.StringValue("python manage.py migrate"),
+			Native: &WebServiceDetailsNative{
+				BuildCommand: types.StringValue("pip install -r requirements.txt"),
+				StartCommand: types.StringValue("celery -A tasks worker"),
+			},
+		},
+	}
+}
+
+func staticSite() Service {
+	return Service{
+		ID:         types.StringUnknown(),
+		Name:       types.StringValue("client"),
+		Type:       types.StringValue("static_site"),
+		Repo:       types.StringValue("https://github.com/render-examples/nextjs-hello-world"),
+		Branch:     types.StringUnknown(),
+		Owner:      types.StringUnknown(),
+		AutoDeploy: types.BoolUnknown(),
+		StaticSiteDetails: &StaticSiteDetails{
+			BuildCommand:               types.StringValue("yarn; yarn build; yarn next export"),
+			PublishPath:                types.StringValue("out"),
+			PullRequestPreviewsEnabled: types.BoolValue(true),
+			Url:                        types.StringUnknown(),
+		},
+	}
+}
+
+func TestServiceRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		plan   func() Service
+		update func(*Service)
+	}{
+		{
+			name: "web service",
+			plan: webService,
+			update: func(s *Service) {
+				s.Name = types.StringValue("api-renamed")
+				s.AutoDeploy = types.BoolValue(true)
+				s.WebServiceDetails.Plan = types.StringValue("standard")
+				s.WebServiceDetails.PullRequestPreviewsEnabled = types.BoolValue(false)
+				s.WebServiceDetails.Native.StartCommand = types.StringValue("npm start")
+				s.WebServiceDetails.PreDeployCommand = types.StringNull()
+			},
+		},
+		{
+			name: "monorepo web service",
+			plan: func() Service {
+				s := webService()
+				s.RootDirectory = types.StringValue("services/api")
+				s.BuildFilter = &BuildFilter{
+					Paths:        []types.String{types.StringValue("services/api/**")},
+					IgnoredPaths: []types.String{types.StringValue("**/*.md")},
+				}
+				return s
+			},
+			update: func(s *Service) {
+				s.RootDirectory = types.StringNull()
+				s.BuildFilter = nil
+			},
+		},
+		{
+			name: "web service with previews",
+			plan: func() Service {
+				s := webService()
+				s.WebServiceDetails.PullRequestPreviewsEnabled = types.BoolNull()
+				s.Previews = &Previews{
+					Generation:      types.StringValue("automatic"),
+					ExpireAfterDays: types.Int64Value(3),
+					Plan:            types.StringValue("starter"),
+				}
+				return s
+			},
+			update: func(s *Service) {
+				s.Previews = &Previews{
+					Generation:      types.StringValue("manual"),
+					ExpireAfterDays: types.Int64Null(),
+					Plan:            types.StringNull(),
+				}
+			},
+		},
+		{
+			name: "docker web service",
+			plan: dockerWebService,
+			update: func(s *Service) {
+				s.WebServiceDetails.HealthCheckPath = types.StringValue("/ready")
+				s.WebServiceDetails.PreDeployCommand = types.StringValue("./migrate.sh")
+				s.WebServiceDetails.MaxShutdownDelaySeconds = types.Int64Value(300)
+				s.WebServiceDetails.DockerCommand = types.StringNull()
+			},
+		},
+		{
+			name: "private service",
+			plan: privateService,
+			update: func(s *Service) {
+				s.PrivateServiceDetails.Plan = types.StringValue("pro")
+				s.PrivateServiceDetails.Disk.SizeGB = types.Int64Value(20)
+				s.PrivateServiceDetails.PreDeployCommand = types.StringValue("./migrate.sh")
+			},
+		},
+		{
+			name: "background worker",
+			plan: backgroundWorker,
+			update: func(s *Service) {
+				s.BackgroundWorkerDetails.Plan = types.StringValue("standard")
+				s.BackgroundWorkerDetails.PreDeployCommand = types.StringValue("alembic upgrade head")
+				s.BackgroundWorkerDetails.Native.StartCommand = types.StringValue("celery -A tasks worker -B")
+			},
+		},
+		{
+			name: "static site",
+			plan: staticSite,
+			update: func(s *Service) {
+				s.StaticSiteDetails.PublishPath = types.StringValue("dist")
+				s.StaticSiteDetails.PullRequestPreviewsEnabled = types.BoolNull()
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := fakerender.New()
+			defer server.Close()
+
+			client := api.NewClient(server.URL)
+			ctx := context.Background()
+			plan := test.plan()
+
+			post, err := plan.ToServicePOST("usr-00000001")
+
+			if err != nil {
+				t.Fatalf("ToServicePOST: %s", err)
+			}
+
+			created, err := client.CreateServiceWithResponse(ctx, *post)
+
+			if err != nil || created.StatusCode() != http.StatusCreated {
+				t.Fatalf("create: %v %s", err, created.Body)
+			}
+
+			state := plan.FromResponse(*created.JSON201.Service)
+			checkConsistent(t, "", reflect.ValueOf(plan), reflect.ValueOf(state))
+			checkStableRead(t, client, state)
+
+			update := state
+			update.WebServiceDetails = copyPtr(state.WebServiceDetails)
+			update.PrivateServiceDetails = copyPtr(state.PrivateServiceDetails)
+			update.StaticSiteDetails = copyPtr(state.StaticSiteDetails)
+			update.BackgroundWorkerDetails = copyPtr(state.BackgroundWorkerDetails)
+
+			if update.WebServiceDetails != nil {
+				update.WebServiceDetails.Native = copyPtr(state.WebServiceDetails.Native)
+			}
+
+			if update.PrivateServiceDetails != nil {
+				update.PrivateServiceDetails.Disk = copyPtr(state.PrivateServiceDetails.Disk)
+			}
+
+			if update.BackgroundWorkerDetails != nil {
+				update.BackgroundWorkerDetails.Native = copyPtr(state.BackgroundWorkerDetails.Native)
+			}
+
+			test.update(&update)
+
+			patch, err := update.ToServicePATCH()
+
+			if err != nil {
+				t.Fatalf("ToServicePATCH: %s", err)
+			}
+
+			updated, err := client.UpdateServiceWithResponse(ctx, state.ID.ValueString(), *patch)
+
+			if err != nil || updated.StatusCode() != http.StatusOK {
+				t.Fatalf("update: %v %s", err, updated.Body)
+			}
+
+			state = update.FromResponse(*updated.JSON200)
+			checkConsistent(t, "", reflect.ValueOf(update), reflect.ValueOf(state))
+			checkStableRead(t, client, state)
+		})
+	}
+}
+
+func TestServiceFromResponseIgnoresDockerDetails(t *testing.T) {
+	var response api.Service
+
+	err := json.Unmarshal([]byte(`{
+		"id": "srv-1",
+		"type": "web_service",
+		"serviceDetails": {
+			"env": "docker",
+			"envSpecificDetails": {"dockerfilePath": "./Dockerfile", "dockerContext": ".", "preDeployCommand": "./migrate.sh"}
+		}
+	}`), &response)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := dockerWebService().FromResponse(response)
+
+	if result.WebServiceDetails.Native != nil {
+		t.Fatalf("expected no native details for a docker service, got %+v", result.WebServiceDetails.Native)
+	}
+
+	if !result.WebServiceDetails.PreDeployCommand.Equal(types.StringValue("./migrate.sh")) {
+		t.Errorf("expected the pre-deploy command of a docker service to be read back, got %s", result.WebServiceDetails.PreDeployCommand)
+	}
+}
+
+func TestServiceFromResponseKeepsUnmanagedPreviewsNull(t *testing.T) {
+	var response api.Service
+
+	err := json.Unmarshal([]byte(`{
+		"id": "srv-1",
+		"type": "static_site",
+		"autoDeploy": "yes",
+		"serviceDetails": {"pullRequestPreviewsEnabled": "yes"}
+	}`), &response)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prior := staticSite()
+	prior.StaticSiteDetails.PullRequestPreviewsEnabled = types.BoolNull()
+
+	result := prior.FromResponse(response)
+
+	if !result.AutoDeploy.Equal(types.BoolValue(true)) {
+		t.Errorf("expected auto_deploy to be read back, got %s", result.AutoDeploy)
+	}
+
+	if !result.StaticSiteDetails.PullRequestPreviewsEnabled.IsNull() {
+		t.Errorf("expected pull_request_previews_enabled to stay null, got %s", result.StaticSiteDetails.PullRequestPreviewsEnabled)
+	}
+}
+
+func TestServiceFromResponseReadsManagedPreviews(t *testing.T) {
+	var response api.Service
+
+	err := json.Unmarshal([]byte(`{
+		"id": "srv-1",
+		"type": "static_site",
+		"serviceDetails": {"publishPath": "out"}
+	}`), &response)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result := staticSite().FromResponse(response); result.Previews != nil {
+		t.Errorf("expected unmanaged previews to stay null, got %+v", result.Previews)
+	}
+
+	prior := staticSite()
+	prior.Previews = &Previews{Generation: types.StringValue("automatic")}
+
+	result := prior.FromResponse(response)
+
+	if result.Previews == nil || !result.Previews.Generation.Equal(types.StringValue("off")) {
+		t.Errorf("expected a service without previews to read back as `off`, got %+v", result.Previews)
+	}
+}
+
+func TestServiceFromResponseReadsManagedEnvironment(t *testing.T) {
+	var response api.Service
+
+	err := json.Unmarshal([]byte(`{
+		"id": "srv-1",
+		"type": "static_site",
+		"environmentId": "evm-1",
+		"serviceDetails": {"publishPath": "out"}
+	}`), &response)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result := staticSite().FromResponse(response); !result.EnvironmentID.IsNull() {
+		t.Errorf("expected an unmanaged environment_id to stay null, got %s", result.EnvironmentID)
+	}
+
+	prior := staticSite()
+	prior.EnvironmentID = types.StringValue("evm-0")
+
+	if result := prior.FromResponse(response); !result.EnvironmentID.Equal(types.StringValue("evm-1")) {
+		t.Errorf("expected a managed environment_id to be read back, got %s", result.EnvironmentID)
+	}
+}
+
+func TestServiceFromResponseWithoutType(t *testing.T) {
+	result := Service{}.FromResponse(api.Service{})
+
+	if !result.Type.IsNull() {
+		t.Fatalf("expected a null type, got %s", result.Type)
+	}
+}
+
+func TestServiceToServicePOSTErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		service func() Service
+		error   string
+	}{
+		{
+			name: "missing web service details",
+			service: func() Service {
+				s := webService()
+				s.WebServiceDetails = nil
+				return s
+			},
+			error: "'web_service_details' is required",
+		},
+		{
+			name: "static site details on a web service",
+			service: func() Service {
+				s := webService()
+				s.StaticSiteDetails = staticSite().StaticSiteDetails
+				return s
+			},
+			error: "'static_site_details' can only be used",
+		},
+		{
+			name: "missing private service details",
+			service: func() Service {
+				s := privateService()
+				s.PrivateServiceDetails = nil
+				return s
+			},
+			error: "'private_service_details' is required",
+		},
+		{
+			name: "web service details on a private service",
+			service: func() Service {
+				s := privateService()
+				s.WebServiceDetails = webService().WebServiceDetails
+				return s
+			},
+			error: "'web_service_details' can only be used",
+		},
+		{
+			name: "docker command on a native web service",
+			service: func() Service {
+				s := webService()
+				s.WebServiceDetails.DockerCommand = types.StringValue("./server")
+				return s
+			},
+			error: "'docker_command' can only be used",
+		},
+		{
+			name: "previews on a background worker",
+			service: func() Service {
+				s := backgroundWorker()
+				s.Previews = &Previews{Generation: types.StringValue("automatic")}
+				return s
+			},
+			error: "'previews' can only be used",
+		},
+		{
+			name: "missing background worker details",
+			service: func() Service {
+				s := backgroundWorker()
+				s.BackgroundWorkerDetails = nil
+				return s
+			},
+			error: "'background_worker_details' is required",
+		},
+		{
+			name: "background worker details on a web service",
+			service: func() Service {
+				s := webService()
+				s.BackgroundWorkerDetails = backgroundWorker().BackgroundWorkerDetails
+				return s
+			},
+			error: "'background_worker_details' can only be used",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			service := test.service()
+
+			if _, err := service.ToServicePOST("usr-1"); err == nil || !strings.Contains(err.Error(), test.error) {
+				t.Errorf("ToServicePOST: expected error containing %q, got %v", test.error, err)
+			}
+
+			if _, err := service.ToServicePATCH(); err == nil || !strings.Contains(err.Error(), test.error) {
+				t.Errorf("ToServicePATCH: expected error containing %q, got %v", test.error, err)
+			}
+		})
+	}
+}
+
+func FuzzServiceFromResponse(f *testing.F) {
+	f.Add([]byte(`{"id":"srv-1","type":"web_service","serviceDetails":{"env":"node","envSpecificDetails":{"buildCommand":"npm install"}}}`))
+	f.Add([]byte(`{"id":"srv-1","type":"private_service","serviceDetails":{"disk":{"id":"dsk-1","name":"db"}}}`))
+	f.Add([]byte(`{"id":"srv-1","type":"static_site","autoDeploy":"no","serviceDetails":{"pullRequestPreviewsEnabled":"yes"}}`))
+	f.Add([]byte(`{"id":"srv-1","type":"web_service","rootDir":"api","buildFilter":{"paths":["api/**"],"ignoredPaths":[]}}`))
+	f.Add([]byte(`{"type":"web_service","serviceDetails":[]}`))
+	f.Add([]byte(`{}`))
+
+	priors := []Service{{}, webService(), dockerWebService(), privateService(), backgroundWorker(), staticSite()}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var response api.Service
+
+		if json.Unmarshal(data, &response) != nil {
+			return
+		}
+
+		for _, prior := range priors {
+			prior.FromResponse(response)
+		}
+	})
+}
+
+func FuzzWebServiceRoundTrip(f *testing.F) {
+	f.Add("api", "main", "npm install", "node server.js", "/health", uint8(0), true, false)
+	f.Add("", "feature/x", "", "", "", uint8(7), false, true)
+
+	server := fakerender.New()
+	defer server.Close()
+
+	f.Fuzz(func(t *testing.T, name string, branch string, buildCommand string, startCommand string, healthCheckPath string, env uint8, autoDeploy bool, previews bool) {
+		for _, value := range []string{name, branch, buildCommand, startCommand, healthCheckPath} {
+			if !utf8.ValidString(value) {
+				return
+			}
+		}
+
+		// Empty branches are not sent, Render falls back to the default branch
+		if branch == "" {
+			return
+		}
+
+		envs := []render.ServiceEnv{render.Node, render.Docker, render.Go, render.Python, render.Ruby, render.Rust, render.Elixir}
+
+		plan := webService()
+		plan.Name = types.StringValue(name)
+		plan.Branch = types.StringValue(branch)
+		plan.AutoDeploy = types.BoolValue(autoDeploy)
+		plan.WebServiceDetails.Env = types.StringValue(string(envs[int(env)%len(envs)]))
+		plan.WebServiceDetails.HealthCheckPath = types.StringValue(healthCheckPath)
+		plan.WebServiceDetails.PullRequestPreviewsEnabled = types.BoolValue(previews)
+		plan.WebServiceDetails.Native.BuildCommand = types.StringValue(buildCommand)
+		plan.WebServiceDetails.Native.StartCommand = types.StringValue(startCommand)
+
+		if plan.WebServiceDetails.Env.ValueString() == string(render.Docker) {
+			plan.WebServiceDetails.Native = nil
+		}
+
+		post, err := plan.ToServicePOST("usr-00000001")
+
+		if err != nil {
+			t.Fatalf("ToServicePOST: %s", err)
+		}
+
+		id, err := server.AddService(*post)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		response, _ := server.Service(id)
+		state := plan.FromResponse(response)
+
+		checkConsistent(t, "", reflect.ValueOf(plan), reflect.ValueOf(state))
+
+		if reread := state.FromResponse(response); !reflect.DeepEqual(reread, state) {
+			t.Fatalf("read is not stable:\n%+v\n%+v", state, reread)
+		}
+	})
+}
+
+// checkStableRead fails when reading the service again would produce a diff.
+func checkStableRead(t *testing.T, client *api.Client, state Service) {
+	t.Helper()
+
+	response, err := client.GetServiceWithResponse(context.Background(), state.ID.ValueString())
+
+	if err != nil || response.StatusCode() != http.StatusOK {
+		t.Fatalf("read: %v %s", err, response.Body)
+	}
+
+	if read := state.FromResponse(*response.JSON200); !reflect.DeepEqual(read, state) {
+		t.Fatalf("read is not stable:\n%+v\n%+v", state, read)
+	}
+}
+
+// checkConsistent applies Terraform's rules for the result of an apply: known
+// planned values are kept, unknown ones are resolved and absent blocks stay absent.
+func checkConsistent(t *testing.T, path string, plan reflect.Value, state reflect.Value) {
+	t.Helper()
+
+	if value, ok := plan.Interface().(attr.Value); ok {
+		result := state.Interface().(attr.Value)
+
+		if value.IsUnknown() {
+			if result.IsUnknown() {
+				t.Errorf("%s: unknown after apply", path)
+			}
+		} else if !value.Equal(result) {
+			t.Errorf("%s: planned %s, got %s", path, value, result)
+		}
+
+		return
+	}
+
+	switch plan.Kind() {
+	case reflect.Pointer:
+		if plan.IsNil() != state.IsNil() {
+			t.Errorf("%s: planned nil %t, got nil %t", path, plan.IsNil(), state.IsNil())
+			return
+		}
+
+		if !plan.IsNil() {
+			checkConsistent(t, path, plan.Elem(), state.Elem())
+		}
+	case reflect.Slice:
+		if plan.IsNil() != state.IsNil() || plan.Len() != state.Len() {
+			t.Errorf("%s: planned %d elements (nil %t), got %d (nil %t)", path, plan.Len(), plan.IsNil(), state.Len(), state.IsNil())
+			return
+		}
+
+		for i := 0; i < plan.Len(); i++ {
+			checkConsistent(t, fmt.Sprintf("%s.%d", path, i), plan.Index(i), state.Index(i))
+		}
+	case reflect.Struct:
+		for i := 0; i < plan.NumField(); i++ {
+			field := plan.Type().Field(i)
+			checkConsistent(t, strings.TrimPrefix(path+"."+field.Tag.Get("tfsdk"), "."), plan.Field(i), state.Field(i))
+		}
+	}
+}
+
+func copyPtr[T any](value *T) *T {
+	if value == nil {
+		return nil
+	}
+
+	result := *value
+
+	return &result
+}
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// timeoutsAttributeTypes match `timeouts.AttributesAll`, which every resource uses.
+var timeoutsAttributeTypes = map[string]attr.Type{
+	"create": types.StringType,
+	"read":   types.StringType,
+	"update": types.StringType,
+	"delete": types.StringType,
+}
+
+// NullTimeouts returns an unset `timeouts` attribute, e.g. for upgraded state.
+func NullTimeouts() timeouts.Value {
+	return timeouts.Value{Object: types.ObjectNull(timeoutsAttributeTypes)}
+}
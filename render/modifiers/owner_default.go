@@ -43,7 +43,7 @@ func (apm *ownerDefaultPlanModifier) PlanModifyString(ctx context.Context, req p
 	}
 
 	if apm.Owner == nil {
-		res.Diagnostics.AddError("failed to set an owner", "'owner' is required if a global email is not set")
+		res.Diagnostics.AddError("failed to set an owner", "'owner' is required if the provider has no 'owner_id', 'owner_name' or 'email'")
 		return
 	}
 
@@ -0,0 +1,87 @@
+package render
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jackall3n/render-go"
+)
+
+func TestAccOwnerDataSource(t *testing.T) {
+	server := newTestAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(server, `
+data "render_owner" "me" {
+  email = "jane@example.com"
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.render_owner.me", "id", testAccOwnerID),
+					resource.TestCheckResourceAttr("data.render_owner.me", "name", "Jane"),
+					resource.TestCheckResourceAttr("data.render_owner.me", "type", "user"),
+				),
+			},
+			{
+				Config: testAccConfig(server, `
+data "render_owner" "unknown" {
+  email = "john@example.com"
+}
+`),
+				ExpectError: regexp.MustCompile(`no owner was found for email \[john@example.com\]`),
+			},
+		},
+	})
+}
+
+func TestAccOwnersDataSource(t *testing.T) {
+	server := newTestAccServer(t)
+
+	teamType := render.OwnerTypeTeam
+
+	for _, team := range []struct{ id, name string }{{"tea-00000001", "Platform"}, {"tea-00000002", "Staging"}} {
+		name := team.name
+		server.AddOwner(render.Owner{Id: team.id, Name: &name, Type: &teamType})
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(server, `
+data "render_owners" "invalid" {
+  type = "organization"
+}
+`),
+				ExpectError: regexp.MustCompile(`value must be one of`),
+			},
+			{
+				Config: testAccConfig(server, `
+data "render_owners" "all" {}
+
+data "render_owners" "teams" {
+  type = "team"
+}
+
+data "render_owners" "platform" {
+  name = "Platform"
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.render_owners.all", "owners.#", "3"),
+					resource.TestCheckResourceAttr("data.render_owners.all", "owners.0.id", testAccOwnerID),
+					resource.TestCheckResourceAttr("data.render_owners.all", "owners.0.email", testAccOwnerEmail),
+					resource.TestCheckResourceAttr("data.render_owners.all", "owners.0.type", "user"),
+					resource.TestCheckResourceAttr("data.render_owners.teams", "owners.#", "2"),
+					resource.TestCheckResourceAttr("data.render_owners.teams", "owners.1.name", "Staging"),
+					resource.TestCheckResourceAttr("data.render_owners.platform", "owners.#", "1"),
+					resource.TestCheckResourceAttr("data.render_owners.platform", "owners.0.id", "tea-00000001"),
+				),
+			},
+		},
+	})
+}
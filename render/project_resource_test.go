@@ -0,0 +1,203 @@
+package render
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/jackall3n/terraform-provider-render/internal/fakerender"
+	"github.com/jackall3n/terraform-provider-render/render/api"
+)
+
+func testAccProjectConfig(name string, serviceEnvironment string) string {
+	environmentId := ""
+
+	if serviceEnvironment != "" {
+		environmentId = fmt.Sprintf("environment_id = render_project_environment.%s.id", serviceEnvironment)
+	}
+
+	return fmt.Sprintf(`
+resource "render_project" "app" {
+  name = %q
+}
+
+resource "render_project_environment" "production" {
+  project_id = render_project.app.id
+  name       = "Production"
+  protected  = true
+}
+
+resource "render_project_environment" "staging" {
+  project_id                = render_project.app.id
+  name                      = "Staging"
+  network_isolation_enabled = true
+}
+
+resource "render_service" "api" {
+  name = "api"
+  repo = "https://github.com/acme/api"
+  type = "web_service"
+  %s
+
+  web_service_details = {
+    env = "node"
+  }
+}
+`, name, environmentId)
+}
+
+// testAccCheckEnvironmentServices fails when the services in the environment of a resource are not serviceIds.
+func testAccCheckEnvironmentServices(server *fakerender.Server, name string, serviceIds ...string) func(*terraform.State) error {
+	return func(s *terraform.State) error {
+		environment, ok := server.Environment(s.RootModule().Resources[name].Primary.Attributes["id"])
+
+		if !ok {
+			return fmt.Errorf("environment of %s not found", name)
+		}
+
+		if fmt.Sprint(environment.ServiceIds) != fmt.Sprint(serviceIds) {
+			return fmt.Errorf("expected services %v in %s, got %v", serviceIds, name, environment.ServiceIds)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckProjectsDestroyed(server *fakerender.Server) func(*terraform.State) error {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "render_project" {
+				continue
+			}
+
+			if _, ok := server.Project(rs.Primary.Attributes["id"]); ok {
+				return fmt.Errorf("project %s still exists", rs.Primary.Attributes["id"])
+			}
+		}
+
+		return testAccCheckServicesDestroyed(server)(s)
+	}
+}
+
+func TestAccProjectResource(t *testing.T) {
+	server := newTestAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckProjectsDestroyed(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(server, testAccProjectConfig("app", "staging")),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("render_project.app", "owner", testAccOwnerID),
+					resource.TestCheckResourceAttr("render_project.app", "initial_environment", "Production"),
+					// The initial environment is taken over rather than created again
+					func(s *terraform.State) error {
+						project, _ := server.Project(s.RootModule().Resources["render_project.app"].Primary.Attributes["id"])
+
+						if len(project.EnvironmentIds) != 2 {
+							return fmt.Errorf("expected 2 environments, got %v", project.EnvironmentIds)
+						}
+
+						return nil
+					},
+					resource.TestCheckResourceAttr("render_project_environment.production", "protected", "true"),
+					resource.TestCheckResourceAttr("render_project_environment.production", "network_isolation_enabled", "false"),
+					resource.TestCheckResourceAttr("render_project_environment.staging", "protected", "false"),
+					resource.TestCheckResourceAttr("render_project_environment.staging", "network_isolation_enabled", "true"),
+					resource.TestCheckResourceAttrPair("render_service.api", "environment_id", "render_project_environment.staging", "id"),
+					testAccCheckEnvironmentServices(server, "render_project_environment.staging", "srv-00000001"),
+					testAccCheckEnvironmentServices(server, "render_project_environment.production"),
+				),
+			},
+			{
+				Config: testAccConfig(server, testAccProjectConfig("acme", "production")),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("render_project.app", plancheck.ResourceActionUpdate),
+						plancheck.ExpectResourceAction("render_service.api", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("render_project.app", "name", "acme"),
+					resource.TestCheckResourceAttr("render_project.app", "environment_ids.#", "2"),
+					resource.TestCheckResourceAttrPair("render_service.api", "environment_id", "render_project_environment.production", "id"),
+					testAccCheckEnvironmentServices(server, "render_project_environment.production", "srv-00000001"),
+					testAccCheckEnvironmentServices(server, "render_project_environment.staging"),
+				),
+			},
+			{
+				Config: testAccConfig(server, testAccProjectConfig("acme", "")),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("render_service.api", "environment_id"),
+					testAccCheckEnvironmentServices(server, "render_project_environment.production"),
+				),
+			},
+			{
+				ResourceName:            "render_project.app",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts", "initial_environment"},
+			},
+			{
+				ResourceName:            "render_project_environment.staging",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+		},
+	})
+}
+
+func TestAccProjectResourceImportAndRename(t *testing.T) {
+	server := newTestAccServer(t)
+
+	id := server.AddProject(api.ProjectPOST{
+		Name:         "app",
+		OwnerId:      testAccOwnerID,
+		Environments: []api.EnvironmentInput{{Name: "Production"}},
+	})
+
+	config := func(name string) string {
+		return testAccConfig(server, fmt.Sprintf(`
+import {
+  to = render_project.app
+  id = %q
+}
+
+resource "render_project" "app" {
+  name = %q
+}
+`, id, name))
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckProjectsDestroyed(server),
+		Steps: []resource.TestStep{
+			// Imported projects have no initial_environment, renaming them must keep it null
+			{
+				Config: config("acme"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("render_project.app", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("render_project.app", "id", id),
+					resource.TestCheckResourceAttr("render_project.app", "name", "acme"),
+					resource.TestCheckNoResourceAttr("render_project.app", "initial_environment"),
+				),
+			},
+			{
+				Config: config("acme-prod"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("render_project.app", "name", "acme-prod"),
+					resource.TestCheckNoResourceAttr("render_project.app", "initial_environment"),
+				),
+			},
+		},
+	})
+}
@@ -8,11 +8,15 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/jackall3n/render-go"
+	"github.com/jackall3n/terraform-provider-render/render/api"
 	"github.com/jackall3n/terraform-provider-render/render/datasources"
 	"github.com/jackall3n/terraform-provider-render/render/resources"
+	rendertypes "github.com/jackall3n/terraform-provider-render/render/types"
+	"github.com/jackall3n/terraform-provider-render/render/validators"
 	"os"
 )
 
@@ -42,6 +46,43 @@ The Render provider is used to interact with resources supported by Render.
 				Description: "Your Render email. This is used as a default `owner` in all services where no owner is specified. If not supplied, `RENDER_EMAIL` is used",
 				Optional:    true,
 			},
+			"owner_id": schema.StringAttribute{
+				Description: "The ID of the user or team used as a default `owner`. Takes precedence over `owner_name` and `email`. If not supplied, `RENDER_OWNER_ID` is used",
+				Optional:    true,
+			},
+			"owner_name": schema.StringAttribute{
+				Description: "The name of the user or team used as a default `owner`. Takes precedence over `email`. If not supplied, `RENDER_OWNER_NAME` is used",
+				Optional:    true,
+			},
+			"api_url": schema.StringAttribute{
+				Description: "The base URL of the Render API, e.g. to run against a local fake. If not supplied, `RENDER_API_URL` is used, falling back to `https://api.render.com/v1`",
+				Optional:    true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"defaults": schema.SingleNestedBlock{
+				Description: "Defaults for services that don't set these values themselves.",
+				Attributes: map[string]schema.Attribute{
+					"region": schema.StringAttribute{
+						Description: "The default `region` of web, private and background worker services.",
+						Optional:    true,
+						Validators:  []validator.String{validators.Region()},
+					},
+					"plan": schema.StringAttribute{
+						Description: "The default `plan` of web, private and background worker services.",
+						Optional:    true,
+						Validators:  []validator.String{validators.Plan()},
+					},
+					"branch": schema.StringAttribute{
+						Description: "The default `branch` of services.",
+						Optional:    true,
+					},
+					"auto_deploy": schema.BoolAttribute{
+						Description: "The default `auto_deploy` of services.",
+						Optional:    true,
+					},
+				},
+			},
 		},
 	}
 }
@@ -51,18 +92,59 @@ func (p *renderProvider) Resources(_ context.Context) []func() resource.Resource
 		resources.ServiceResource,
 		resources.ServiceEnvironmentResource,
 		resources.ServiceCustomDomainResource,
+		resources.ServiceHeadersResource,
+		resources.ServiceRestartResource,
+		resources.ServiceRollbackResource,
+		resources.BlueprintResource,
+		resources.ProjectResource,
+		resources.ProjectEnvironmentResource,
 	}
 }
 
 func (p *renderProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		datasources.OwnerDataSource,
+		datasources.OwnersDataSource,
+		datasources.RegionsDataSource,
+		datasources.ServiceCustomDomainsDataSource,
+		datasources.BlueprintDataSource,
 	}
 }
 
 type ProviderData struct {
-	APIKey types.String `tfsdk:"api_key"`
-	Email  types.String `tfsdk:"email"`
+	APIKey    types.String `tfsdk:"api_key"`
+	Email     types.String `tfsdk:"email"`
+	OwnerID   types.String `tfsdk:"owner_id"`
+	OwnerName types.String `tfsdk:"owner_name"`
+	APIURL    types.String `tfsdk:"api_url"`
+
+	Defaults *ProviderDefaults `tfsdk:"defaults"`
+}
+
+type ProviderDefaults struct {
+	Region     types.String `tfsdk:"region"`
+	Plan       types.String `tfsdk:"plan"`
+	Branch     types.String `tfsdk:"branch"`
+	AutoDeploy types.Bool   `tfsdk:"auto_deploy"`
+}
+
+func (d *ProviderDefaults) toDefaults() rendertypes.Defaults {
+	defaults := rendertypes.Defaults{}
+
+	if d == nil {
+		return defaults
+	}
+
+	defaults.Region = d.Region.ValueString()
+	defaults.Plan = d.Plan.ValueString()
+	defaults.Branch = d.Branch.ValueString()
+
+	if !d.AutoDeploy.IsNull() {
+		autoDeploy := d.AutoDeploy.ValueBool()
+		defaults.AutoDeploy = &autoDeploy
+	}
+
+	return defaults
 }
 
 func (p *renderProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
@@ -110,16 +192,41 @@ func (p *renderProvider) Configure(ctx context.Context, req provider.ConfigureRe
 
 	tflog.Debug(ctx, fmt.Sprintf("email: %s", email))
 
+	owner := ownerConfig{
+		ID:    stringOrEnv(config.OwnerID, "RENDER_OWNER_ID"),
+		Name:  stringOrEnv(config.OwnerName, "RENDER_OWNER_NAME"),
+		Email: email,
+	}
+
+	apiURL := host
+
+	if !config.APIURL.IsNull() {
+		apiURL = config.APIURL.ValueString()
+	} else if url := os.Getenv("RENDER_API_URL"); url != "" {
+		apiURL = url
+	}
+
 	bearer, _ := securityprovider.NewSecurityProviderBearerToken(apiKey)
-	client, _ := render.NewClientWithResponses(host, render.WithRequestEditorFn(bearer.Intercept))
+	client, _ := render.NewClientWithResponses(apiURL, render.WithRequestEditorFn(bearer.Intercept))
+	apiClient := api.NewClient(apiURL, bearer.Intercept)
 
-	c, err := createContext(ctx, client, email)
+	c, err := createContext(ctx, client, apiClient, owner)
 
 	if err != nil {
 		resp.Diagnostics.AddError("failed to create context", err.Error())
 		return
 	}
 
+	c.Defaults = config.Defaults.toDefaults()
+
 	resp.DataSourceData = c
 	resp.ResourceData = c
 }
+
+func stringOrEnv(value types.String, key string) string {
+	if value.IsNull() {
+		return os.Getenv(key)
+	}
+
+	return value.ValueString()
+}
@@ -0,0 +1,145 @@
+package render
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/jackall3n/render-go"
+	"github.com/jackall3n/terraform-provider-render/internal/fakerender"
+)
+
+const (
+	testAccOwnerID    = "usr-00000001"
+	testAccOwnerEmail = "jane@example.com"
+)
+
+var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"render": providerserver.NewProtocol6WithError(New()),
+}
+
+// newTestAccServer starts a fake Render API with a single user owner.
+func newTestAccServer(t *testing.T) *fakerender.Server {
+	t.Helper()
+
+	server := fakerender.New()
+	t.Cleanup(server.Close)
+
+	name := "Jane"
+	email := testAccOwnerEmail
+	ownerType := render.OwnerTypeUser
+
+	server.AddOwner(render.Owner{Id: testAccOwnerID, Name: &name, Email: &email, Type: &ownerType})
+
+	return server
+}
+
+// testAccConfig prefixes config with a provider block pointing at server.
+func testAccConfig(server *fakerender.Server, config string) string {
+	return fmt.Sprintf(`
+provider "render" {
+  api_key = "rnd_test"
+  api_url = %q
+  email   = %q
+}
+`, server.URL, testAccOwnerEmail) + config
+}
+
+// testAccCaptureID stores the id of a resource, to compare it in later steps.
+func testAccCaptureID(name string, attribute string, id *string) func(*terraform.State) error {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+
+		if !ok {
+			return fmt.Errorf("resource %s not found", name)
+		}
+
+		*id = rs.Primary.Attributes[attribute]
+
+		return nil
+	}
+}
+
+// testAccCheckChangedID fails when the id of a resource is still the captured one.
+func testAccCheckChangedID(name string, attribute string, id *string) func(*terraform.State) error {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+
+		if !ok {
+			return fmt.Errorf("resource %s not found", name)
+		}
+
+		if rs.Primary.Attributes[attribute] == *id {
+			return fmt.Errorf("expected %s to be replaced, %s is still %s", name, attribute, *id)
+		}
+
+		return nil
+	}
+}
+
+// testAccCheckServicesDestroyed fails when the fake still holds a service.
+func testAccCheckServicesDestroyed(server *fakerender.Server) func(*terraform.State) error {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "render_service" {
+				continue
+			}
+
+			if _, ok := server.Service(rs.Primary.Attributes["id"]); ok {
+				return fmt.Errorf("service %s still exists", rs.Primary.Attributes["id"])
+			}
+		}
+
+		return nil
+	}
+}
+
+// testAccAttributeImportStateId imports using an attribute of another resource.
+func testAccAttributeImportStateId(name string, attribute string) func(*terraform.State) (string, error) {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[name]
+
+		if !ok {
+			return "", fmt.Errorf("resource %s not found", name)
+		}
+
+		return rs.Primary.Attributes[attribute], nil
+	}
+}
+
+var _ plancheck.PlanCheck = expectPlannedValue{}
+
+// expectPlannedValue checks that an attribute is known at plan time and has a value.
+type expectPlannedValue struct {
+	resourceAddress string
+	attributePath   tfjsonpath.Path
+	value           interface{}
+}
+
+func (e expectPlannedValue) CheckPlan(_ context.Context, req plancheck.CheckPlanRequest, resp *plancheck.CheckPlanResponse) {
+	for _, rc := range req.Plan.ResourceChanges {
+		if rc.Address != e.resourceAddress {
+			continue
+		}
+
+		value, err := tfjsonpath.Traverse(rc.Change.After, e.attributePath)
+
+		if err != nil {
+			resp.Error = fmt.Errorf("%s: %s", e.resourceAddress, err)
+			return
+		}
+
+		if value != e.value {
+			resp.Error = fmt.Errorf("%s: expected %s to be planned as %v, got %v", e.resourceAddress, e.attributePath, e.value, value)
+		}
+
+		return
+	}
+
+	resp.Error = fmt.Errorf("%s: not found in plan", e.resourceAddress)
+}
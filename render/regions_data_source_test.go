@@ -0,0 +1,28 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRegionsDataSource(t *testing.T) {
+	server := newTestAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(server, `
+data "render_regions" "all" {}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.render_regions.all", "regions.#", "5"),
+					resource.TestCheckResourceAttr("data.render_regions.all", "regions.0.id", "frankfurt"),
+					resource.TestCheckResourceAttr("data.render_regions.all", "regions.3.id", "singapore"),
+					resource.TestCheckResourceAttr("data.render_regions.all", "regions.3.name", "Singapore (Southeast Asia)"),
+				),
+			},
+		},
+	})
+}
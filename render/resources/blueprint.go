@@ -0,0 +1,433 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jackall3n/terraform-provider-render/render/api"
+	"github.com/jackall3n/terraform-provider-render/render/models"
+	"github.com/jackall3n/terraform-provider-render/render/modifiers"
+	"github.com/jackall3n/terraform-provider-render/render/types"
+	"github.com/jackall3n/terraform-provider-render/render/utils"
+	"net/http"
+	"time"
+)
+
+// blueprintSyncInterval is how often a blueprint is polled while it syncs.
+var blueprintSyncInterval = 5 * time.Second
+
+func BlueprintResource() resource.Resource {
+	return &blueprintResource{}
+}
+
+type blueprintResource struct {
+	api     *api.Client
+	context *types.Context
+}
+
+var _ resource.ResourceWithImportState = (*blueprintResource)(nil)
+var _ resource.ResourceWithModifyPlan = (*blueprintResource)(nil)
+
+func (r *blueprintResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_blueprint"
+}
+
+func (r *blueprintResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	ctx, ok := req.ProviderData.(*types.Context)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *types.Context, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.context = ctx
+	r.api = ctx.API
+}
+
+// Schema returns the schema information for a blueprint resource.
+func (r *blueprintResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	unknown := []planmodifier.String{stringplanmodifier.UseStateForUnknown()}
+	replace := []planmodifier.String{stringplanmodifier.UseStateForUnknown(), stringplanmodifier.RequiresReplace()}
+
+	resp.Schema = schema.Schema{
+		Description: "Manages the link between a repository and its `render.yaml` blueprint. " +
+			"The Render API can't create blueprints, so the blueprint has to be created once with \"New Blueprint Instance\" in the dashboard. " +
+			"Creating this resource takes over the blueprint of `repo` and `branch`, applies its settings and waits for the sync. " +
+			"Destroying it disconnects the blueprint, the resources it created are kept.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{Computed: true, PlanModifiers: unknown},
+			"owner": schema.StringAttribute{
+				Description:   "The ID of the user or team the blueprint belongs to, defaults to the owner of the provider.",
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: replace,
+			},
+			"name": schema.StringAttribute{
+				Description:   "The name of the blueprint. Also selects the blueprint when `repo` and `branch` match more than one.",
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: unknown,
+			},
+			"repo": schema.StringAttribute{
+				Description:   "The repository of the blueprint, e.g. `https://github.com/render-examples/blueprint`.",
+				Required:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"branch": schema.StringAttribute{
+				Description:   "The branch the blueprint syncs from. If not set, any branch of `repo` matches.",
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: replace,
+			},
+			"path": schema.StringAttribute{
+				Description:   "The path of the blueprint file in the repository, e.g. `render.yaml` or `deploy/render.yaml`.",
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: unknown,
+			},
+			"auto_sync": schema.BoolAttribute{
+				Description:   "Whether changes to the blueprint file are synced automatically.",
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
+			},
+
+			"status":    schema.StringAttribute{Computed: true, Description: "One of `created`, `paused`, `in_sync`, `syncing` or `error`."},
+			"last_sync": schema.StringAttribute{Computed: true, Description: "When the blueprint was last synced, in RFC 3339 format."},
+
+			"service_ids": schema.ListAttribute{
+				Description: "The IDs of the services created by the blueprint.",
+				Computed:    true,
+				ElementType: basetypes.StringType{},
+			},
+			"database_ids": schema.ListAttribute{
+				Description: "The IDs of the PostgreSQL and Redis instances created by the blueprint.",
+				Computed:    true,
+				ElementType: basetypes.StringType{},
+			},
+			"resources": schema.ListNestedAttribute{
+				Description: "Every resource created by the blueprint, including environment groups.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id":   schema.StringAttribute{Computed: true},
+						"name": schema.StringAttribute{Computed: true},
+						"type": schema.StringAttribute{Computed: true},
+					},
+				},
+			},
+
+			"timeouts": timeouts.AttributesAll(ctx),
+		},
+	}
+}
+
+func (r *blueprintResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan models.Blueprint
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	ctx, cancel := withTimeout(ctx, plan.Timeouts.Create, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	blueprint, err := r.findBlueprint(ctx, plan)
+
+	if err != nil {
+		resp.Diagnostics.AddError("failed to find blueprint", err.Error())
+		return
+	}
+
+	tflog.Debug(ctx, "taking over blueprint", map[string]interface{}{
+		"id":   blueprint.Id,
+		"repo": blueprint.Repo,
+	})
+
+	plan.ID = basetypes.NewStringValue(blueprint.Id)
+
+	result, err := r.updateBlueprint(ctx, plan)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to sync blueprint",
+			fmt.Sprintf("Could not sync blueprint %s, unexpected error: %s", blueprint.Id, err.Error()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, result)...)
+}
+
+func (r *blueprintResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state models.Blueprint
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	ctx, cancel := withTimeout(ctx, state.Timeouts.Read, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "reading blueprint", map[string]interface{}{
+		"id": state.ID.ValueString(),
+	})
+
+	response, err := r.api.GetBlueprintWithResponse(ctx, state.ID.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading blueprint",
+			fmt.Sprintf("Could not read blueprint %s, unexpected error: %s", state.ID.ValueString(), err),
+		)
+		return
+	}
+
+	if response.StatusCode() == http.StatusNotFound {
+		tflog.Warn(ctx, "blueprint not found, removing it from state", map[string]interface{}{
+			"id": state.ID.ValueString(),
+		})
+
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if response.StatusCode() != http.StatusOK {
+		resp.Diagnostics.AddError("failed to get blueprint", fmt.Sprintf("%s %s", response.Status(), string(response.Body)))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state.FromResponse(*response.JSON200))...)
+}
+
+func (r *blueprintResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan models.Blueprint
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	ctx, cancel := withTimeout(ctx, plan.Timeouts.Update, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.updateBlueprint(ctx, plan)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating blueprint",
+			fmt.Sprintf("Could not update blueprint %s, unexpected error: %s", plan.ID.ValueString(), err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, result)...)
+}
+
+func (r *blueprintResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state models.Blueprint
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	ctx, cancel := withTimeout(ctx, state.Timeouts.Delete, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "disconnecting blueprint", map[string]interface{}{
+		"id": state.ID.ValueString(),
+	})
+
+	response, err := r.api.DisconnectBlueprintWithResponse(ctx, state.ID.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("failed to disconnect blueprint", err.Error())
+		return
+	}
+
+	switch response.StatusCode() {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+	default:
+		resp.Diagnostics.AddError("failed to disconnect blueprint", fmt.Sprintf("%s %s", response.Status(), string(response.Body)))
+	}
+}
+
+func (r *blueprintResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// ModifyPlan defaults the owner of new blueprints to the owner resolved by the provider.
+func (r *blueprintResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || !req.State.Raw.IsNull() || r.context == nil {
+		return
+	}
+
+	modifyPlanString(ctx, req, resp, path.Root("owner"), modifiers.OwnerDefault(r.context.Owner))
+}
+
+// findBlueprint returns the blueprint of the owner that matches `repo`, and `branch` when set.
+// `name` only narrows down the match when there is more than one, so it can also rename the blueprint.
+func (r *blueprintResource) findBlueprint(ctx context.Context, plan models.Blueprint) (*api.Blueprint, error) {
+	var matches []api.Blueprint
+
+	blueprints, err := listBlueprints(ctx, r.api, plan.Owner.ValueString())
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, blueprint := range blueprints {
+		if !utils.SameRepo(blueprint.Repo, plan.Repo.ValueString()) {
+			continue
+		}
+
+		if !plan.Branch.IsUnknown() && !plan.Branch.IsNull() && blueprint.Branch != plan.Branch.ValueString() {
+			continue
+		}
+
+		matches = append(matches, blueprint)
+	}
+
+	if len(matches) > 1 && !plan.Name.IsUnknown() && !plan.Name.IsNull() {
+		var named []api.Blueprint
+
+		for _, blueprint := range matches {
+			if blueprint.Name == plan.Name.ValueString() {
+				named = append(named, blueprint)
+			}
+		}
+
+		matches = named
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf(
+			"no blueprint of owner [%s] syncs from %s. The Render API can't create blueprints, create it once with \"New Blueprint Instance\" in the dashboard",
+			plan.Owner.ValueString(),
+			describeRepo(plan),
+		)
+	case 1:
+		return &matches[0], nil
+	default:
+		return nil, fmt.Errorf("%d blueprints sync from %s, set 'branch' or 'name' to select one", len(matches), describeRepo(plan))
+	}
+}
+
+// updateBlueprint applies the settings of plan to its blueprint and waits for the sync to finish.
+func (r *blueprintResource) updateBlueprint(ctx context.Context, plan models.Blueprint) (models.Blueprint, error) {
+	body := plan.ToBlueprintPATCH()
+
+	tflog.Debug(ctx, "updating blueprint", utils.ToJson(map[string]interface{}{
+		"id":   plan.ID.ValueString(),
+		"body": body,
+	}))
+
+	response, err := r.api.UpdateBlueprintWithResponse(ctx, plan.ID.ValueString(), body)
+
+	if err != nil {
+		return plan, err
+	}
+
+	if response.StatusCode() != http.StatusOK {
+		return plan, fmt.Errorf("%s %s", response.Status(), string(response.Body))
+	}
+
+	blueprint := *response.JSON200
+
+	// The update starts a sync, wait until it has finished.
+	for isSyncing(blueprint) {
+		read, err := r.api.GetBlueprintWithResponse(ctx, plan.ID.ValueString())
+
+		if err != nil {
+			return plan, err
+		}
+
+		if read.StatusCode() != http.StatusOK {
+			return plan, fmt.Errorf("%s %s", read.Status(), string(read.Body))
+		}
+
+		blueprint = *read.JSON200
+
+		if !isSyncing(blueprint) {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return plan, fmt.Errorf("timed out waiting for the sync to finish, raise `timeouts`: %s", ctx.Err())
+		case <-time.After(blueprintSyncInterval):
+		}
+	}
+
+	if blueprint.Status == api.BlueprintError {
+		return plan, fmt.Errorf("the sync failed, check the blueprint in the Render dashboard")
+	}
+
+	return plan.FromResponse(blueprint), nil
+}
+
+// isSyncing reports whether the blueprint has a sync that hasn't finished yet.
+func isSyncing(blueprint api.Blueprint) bool {
+	return blueprint.Status == api.BlueprintSyncing || blueprint.Status == api.BlueprintCreated
+}
+
+// listBlueprints returns every blueprint of an owner.
+func listBlueprints(ctx context.Context, client *api.Client, ownerId string) ([]api.Blueprint, error) {
+	return utils.Paginate(func(cursor *string) (utils.Page[api.Blueprint, string], error) {
+		var page utils.Page[api.Blueprint, string]
+
+		response, err := client.ListBlueprintsWithResponse(ctx, ownerId, cursor, utils.PageLimit)
+
+		if err != nil {
+			return page, err
+		}
+
+		if response.StatusCode() != http.StatusOK {
+			return page, fmt.Errorf("%s %s", response.Status(), string(response.Body))
+		}
+
+		for _, item := range *response.JSON200 {
+			if item.Blueprint != nil {
+				page.Items = append(page.Items, *item.Blueprint)
+			}
+
+			page.Cursor = item.Cursor
+		}
+
+		page.Size = len(*response.JSON200)
+
+		return page, nil
+	})
+}
+
+func describeRepo(plan models.Blueprint) string {
+	if plan.Branch.IsUnknown() || plan.Branch.IsNull() {
+		return plan.Repo.ValueString()
+	}
+
+	return fmt.Sprintf("%s (branch %s)", plan.Repo.ValueString(), plan.Branch.ValueString())
+}
@@ -0,0 +1,40 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackall3n/render-go"
+	"github.com/jackall3n/terraform-provider-render/render/api"
+	"net/http"
+	"time"
+)
+
+// deployInterval is how often a restart or deploy is polled until the service is healthy.
+var deployInterval = 5 * time.Second
+
+// waitForDeploy polls a deploy until it is live or has failed, and returns it.
+func waitForDeploy(ctx context.Context, client *render.ClientWithResponses, serviceId string, deployId string) (render.Deploy, error) {
+	for {
+		response, err := client.GetDeployWithResponse(ctx, serviceId, deployId)
+
+		if err != nil {
+			return render.Deploy{}, err
+		}
+
+		if response.StatusCode() != http.StatusOK {
+			return render.Deploy{}, fmt.Errorf("%s %s", response.Status(), string(response.Body))
+		}
+
+		deploy := *response.JSON200
+
+		if deploy.Status != nil && !api.DeployInProgress(*deploy.Status) {
+			return deploy, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return deploy, fmt.Errorf("timed out waiting for deploy %s to go live, raise `timeouts`: %s", deployId, ctx.Err())
+		case <-time.After(deployInterval):
+		}
+	}
+}
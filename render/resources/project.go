@@ -0,0 +1,257 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jackall3n/terraform-provider-render/render/api"
+	"github.com/jackall3n/terraform-provider-render/render/models"
+	"github.com/jackall3n/terraform-provider-render/render/modifiers"
+	"github.com/jackall3n/terraform-provider-render/render/types"
+	"github.com/jackall3n/terraform-provider-render/render/utils"
+	"net/http"
+)
+
+// defaultEnvironment is the environment created with a project when `initial_environment` isn't set,
+// like in the dashboard.
+const defaultEnvironment = "Production"
+
+func ProjectResource() resource.Resource {
+	return &projectResource{}
+}
+
+type projectResource struct {
+	api     *api.Client
+	context *types.Context
+}
+
+var _ resource.ResourceWithImportState = (*projectResource)(nil)
+var _ resource.ResourceWithModifyPlan = (*projectResource)(nil)
+
+func (r *projectResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project"
+}
+
+func (r *projectResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	ctx, ok := req.ProviderData.(*types.Context)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *types.Context, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.context = ctx
+	r.api = ctx.API
+}
+
+// Schema returns the schema information for a project resource.
+func (r *projectResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	unknown := []planmodifier.String{stringplanmodifier.UseStateForUnknown()}
+	replace := []planmodifier.String{stringplanmodifier.UseStateForUnknown(), stringplanmodifier.RequiresReplace()}
+
+	resp.Schema = schema.Schema{
+		Description: "Manages a project, which groups services into environments, e.g. production and staging. " +
+			"Render projects always have at least one environment, so one is created along with the project. " +
+			"Destroying the project deletes its environments, the services in them are kept.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{Computed: true, PlanModifiers: unknown},
+			"owner": schema.StringAttribute{
+				Description:   "The ID of the user or team the project belongs to, defaults to the owner of the provider.",
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: replace,
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the project.",
+				Required:    true,
+			},
+			"initial_environment": schema.StringAttribute{
+				Description: "The name of the environment created with the project, `" + defaultEnvironment + "` if not set. " +
+					"It is only used on create, manage the environment with a `render_project_environment` of the same name.",
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: unknown,
+			},
+			"environment_ids": schema.ListAttribute{
+				Description: "The IDs of the environments of the project.",
+				Computed:    true,
+				ElementType: basetypes.StringType{},
+			},
+
+			"timeouts": timeouts.AttributesAll(ctx),
+		},
+	}
+}
+
+func (r *projectResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan models.Project
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	ctx, cancel := withTimeout(ctx, plan.Timeouts.Create, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Owner.IsUnknown() || plan.Owner.ValueString() == "" {
+		resp.Diagnostics.AddError("failed to get owner", "'owner' is required if the provider has no 'owner_id', 'owner_name' or 'email'")
+		return
+	}
+
+	if plan.InitialEnvironment.IsUnknown() || plan.InitialEnvironment.IsNull() {
+		plan.InitialEnvironment = basetypes.NewStringValue(defaultEnvironment)
+	}
+
+	body := plan.ToProjectPOST(plan.Owner.ValueString())
+
+	tflog.Debug(ctx, "creating project", utils.ToJson(body))
+
+	response, err := r.api.CreateProjectWithResponse(ctx, body)
+
+	if err != nil {
+		resp.Diagnostics.AddError("failed to create project", err.Error())
+		return
+	}
+
+	if response.StatusCode() != http.StatusCreated {
+		resp.Diagnostics.AddError("failed to create project", fmt.Sprintf("%s %s", response.Status(), string(response.Body)))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan.FromResponse(*response.JSON201))...)
+}
+
+func (r *projectResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state models.Project
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	ctx, cancel := withTimeout(ctx, state.Timeouts.Read, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	response, err := r.api.GetProjectWithResponse(ctx, state.ID.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading project",
+			fmt.Sprintf("Could not read project %s, unexpected error: %s", state.ID.ValueString(), err),
+		)
+		return
+	}
+
+	if response.StatusCode() == http.StatusNotFound {
+		tflog.Warn(ctx, "project not found, removing it from state", map[string]interface{}{
+			"id": state.ID.ValueString(),
+		})
+
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if response.StatusCode() != http.StatusOK {
+		resp.Diagnostics.AddError("failed to get project", fmt.Sprintf("%s %s", response.Status(), string(response.Body)))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state.FromResponse(*response.JSON200))...)
+}
+
+func (r *projectResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state models.Project
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	ctx, cancel := withTimeout(ctx, plan.Timeouts.Update, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// `initial_environment` is only used on create, and null after an import.
+	plan.InitialEnvironment = state.InitialEnvironment
+
+	name := plan.Name.ValueString()
+
+	response, err := r.api.UpdateProjectWithResponse(ctx, state.ID.ValueString(), api.ProjectPATCH{Name: &name})
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating project",
+			fmt.Sprintf("Could not update project %s, unexpected error: %s", state.ID.ValueString(), err),
+		)
+		return
+	}
+
+	if response.StatusCode() != http.StatusOK {
+		resp.Diagnostics.AddError("failed to update project", fmt.Sprintf("%s %s", response.Status(), string(response.Body)))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan.FromResponse(*response.JSON200))...)
+}
+
+func (r *projectResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state models.Project
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	ctx, cancel := withTimeout(ctx, state.Timeouts.Delete, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "deleting project", map[string]interface{}{
+		"id": state.ID.ValueString(),
+	})
+
+	response, err := r.api.DeleteProjectWithResponse(ctx, state.ID.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("failed to delete project", err.Error())
+		return
+	}
+
+	switch response.StatusCode() {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+	default:
+		resp.Diagnostics.AddError("failed to delete project", fmt.Sprintf("%s %s", response.Status(), string(response.Body)))
+	}
+}
+
+func (r *projectResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// ModifyPlan defaults the owner of new projects to the owner resolved by the provider.
+func (r *projectResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || !req.State.Raw.IsNull() || r.context == nil {
+		return
+	}
+
+	modifyPlanString(ctx, req, resp, path.Root("owner"), modifiers.OwnerDefault(r.context.Owner))
+}
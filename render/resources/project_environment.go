@@ -0,0 +1,317 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jackall3n/terraform-provider-render/render/api"
+	"github.com/jackall3n/terraform-provider-render/render/models"
+	"github.com/jackall3n/terraform-provider-render/render/types"
+	"github.com/jackall3n/terraform-provider-render/render/utils"
+	"net/http"
+)
+
+func ProjectEnvironmentResource() resource.Resource {
+	return &projectEnvironmentResource{}
+}
+
+type projectEnvironmentResource struct {
+	api     *api.Client
+	context *types.Context
+}
+
+var _ resource.ResourceWithImportState = (*projectEnvironmentResource)(nil)
+
+func (r *projectEnvironmentResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project_environment"
+}
+
+func (r *projectEnvironmentResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	ctx, ok := req.ProviderData.(*types.Context)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *types.Context, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.context = ctx
+	r.api = ctx.API
+}
+
+// Schema returns the schema information for a project environment resource.
+func (r *projectEnvironmentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	unknown := []planmodifier.String{stringplanmodifier.UseStateForUnknown()}
+	unknownBool := []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()}
+
+	resp.Schema = schema.Schema{
+		Description: "Manages an environment of a project. When the project already has an environment named `name`, " +
+			"e.g. the one created with the project, it is taken over instead of creating another one. " +
+			"Destroying it deletes the environment, the services in it are kept. " +
+			"Place services in it with `environment_id` of `render_service`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{Computed: true, PlanModifiers: unknown},
+			"project_id": schema.StringAttribute{
+				Description:   "The ID of the project, see `render_project`.",
+				Required:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the environment, e.g. `Production` or `Staging`.",
+				Required:    true,
+			},
+			"protected": schema.BoolAttribute{
+				Description:   "Whether only admins can change the environment and the resources in it, e.g. to guard production.",
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: unknownBool,
+			},
+			"network_isolation_enabled": schema.BoolAttribute{
+				Description:   "Whether the private network of the environment is isolated, so services in other environments can't reach its services.",
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: unknownBool,
+			},
+			"service_ids": schema.ListAttribute{
+				Description: "The IDs of the services in the environment.",
+				Computed:    true,
+				ElementType: basetypes.StringType{},
+			},
+
+			"timeouts": timeouts.AttributesAll(ctx),
+		},
+	}
+}
+
+func (r *projectEnvironmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan models.ProjectEnvironment
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	ctx, cancel := withTimeout(ctx, plan.Timeouts.Create, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	existing, err := r.findEnvironment(ctx, plan.ProjectID.ValueString(), plan.Name.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("failed to list environments", err.Error())
+		return
+	}
+
+	if existing != nil {
+		tflog.Debug(ctx, "taking over environment", map[string]interface{}{
+			"id":   existing.Id,
+			"name": existing.Name,
+		})
+
+		plan.ID = basetypes.NewStringValue(existing.Id)
+
+		result, err := r.updateEnvironment(ctx, plan)
+
+		if err != nil {
+			resp.Diagnostics.AddError("failed to update environment", err.Error())
+			return
+		}
+
+		resp.Diagnostics.Append(resp.State.Set(ctx, result)...)
+		return
+	}
+
+	body := plan.ToEnvironmentPOST()
+
+	tflog.Debug(ctx, "creating environment", utils.ToJson(body))
+
+	response, err := r.api.CreateEnvironmentWithResponse(ctx, body)
+
+	if err != nil {
+		resp.Diagnostics.AddError("failed to create environment", err.Error())
+		return
+	}
+
+	if response.StatusCode() != http.StatusCreated {
+		resp.Diagnostics.AddError("failed to create environment", fmt.Sprintf("%s %s", response.Status(), string(response.Body)))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan.FromResponse(*response.JSON201))...)
+}
+
+func (r *projectEnvironmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state models.ProjectEnvironment
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	ctx, cancel := withTimeout(ctx, state.Timeouts.Read, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	response, err := r.api.GetEnvironmentWithResponse(ctx, state.ID.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading environment",
+			fmt.Sprintf("Could not read environment %s, unexpected error: %s", state.ID.ValueString(), err),
+		)
+		return
+	}
+
+	if response.StatusCode() == http.StatusNotFound {
+		tflog.Warn(ctx, "environment not found, removing it from state", map[string]interface{}{
+			"id": state.ID.ValueString(),
+		})
+
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if response.StatusCode() != http.StatusOK {
+		resp.Diagnostics.AddError("failed to get environment", fmt.Sprintf("%s %s", response.Status(), string(response.Body)))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state.FromResponse(*response.JSON200))...)
+}
+
+func (r *projectEnvironmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan models.ProjectEnvironment
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	ctx, cancel := withTimeout(ctx, plan.Timeouts.Update, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.updateEnvironment(ctx, plan)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating environment",
+			fmt.Sprintf("Could not update environment %s, unexpected error: %s", plan.ID.ValueString(), err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, result)...)
+}
+
+func (r *projectEnvironmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state models.ProjectEnvironment
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	ctx, cancel := withTimeout(ctx, state.Timeouts.Delete, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "deleting environment", map[string]interface{}{
+		"id": state.ID.ValueString(),
+	})
+
+	response, err := r.api.DeleteEnvironmentWithResponse(ctx, state.ID.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("failed to delete environment", err.Error())
+		return
+	}
+
+	switch response.StatusCode() {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+	default:
+		resp.Diagnostics.AddError("failed to delete environment", fmt.Sprintf("%s %s", response.Status(), string(response.Body)))
+	}
+}
+
+func (r *projectEnvironmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// updateEnvironment applies the settings of plan to its environment.
+func (r *projectEnvironmentResource) updateEnvironment(ctx context.Context, plan models.ProjectEnvironment) (models.ProjectEnvironment, error) {
+	body := plan.ToEnvironmentPATCH()
+
+	tflog.Debug(ctx, "updating environment", utils.ToJson(map[string]interface{}{
+		"id":   plan.ID.ValueString(),
+		"body": body,
+	}))
+
+	response, err := r.api.UpdateEnvironmentWithResponse(ctx, plan.ID.ValueString(), body)
+
+	if err != nil {
+		return plan, err
+	}
+
+	if response.StatusCode() != http.StatusOK {
+		return plan, fmt.Errorf("%s %s", response.Status(), string(response.Body))
+	}
+
+	return plan.FromResponse(*response.JSON200), nil
+}
+
+// findEnvironment returns the environment of a project with the given name, if there is one.
+func (r *projectEnvironmentResource) findEnvironment(ctx context.Context, projectId string, name string) (*api.Environment, error) {
+	environments, err := utils.Paginate(func(cursor *string) (utils.Page[api.Environment, string], error) {
+		var page utils.Page[api.Environment, string]
+
+		response, err := r.api.ListEnvironmentsWithResponse(ctx, projectId, cursor, utils.PageLimit)
+
+		if err != nil {
+			return page, err
+		}
+
+		if response.StatusCode() != http.StatusOK {
+			return page, fmt.Errorf("%s %s", response.Status(), string(response.Body))
+		}
+
+		for _, item := range *response.JSON200 {
+			if item.Environment != nil {
+				page.Items = append(page.Items, *item.Environment)
+			}
+
+			page.Cursor = item.Cursor
+		}
+
+		page.Size = len(*response.JSON200)
+
+		return page, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, environment := range environments {
+		if environment.Name == name {
+			return &environment, nil
+		}
+	}
+
+	return nil, nil
+}
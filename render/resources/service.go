@@ -3,15 +3,29 @@ package resources
 import (
 	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/boolvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/jackall3n/render-go"
+	"github.com/jackall3n/terraform-provider-render/render/api"
 	"github.com/jackall3n/terraform-provider-render/render/models"
+	"github.com/jackall3n/terraform-provider-render/render/modifiers"
 	"github.com/jackall3n/terraform-provider-render/render/types"
 	"github.com/jackall3n/terraform-provider-render/render/utils"
+	"github.com/jackall3n/terraform-provider-render/render/validators"
 	"net/http"
 )
 
@@ -21,9 +35,13 @@ func ServiceResource() resource.Resource {
 
 type serviceResource struct {
 	client  *render.ClientWithResponses
+	api     *api.Client
 	context *types.Context
 }
 
+var _ resource.ResourceWithImportState = (*serviceResource)(nil)
+var _ resource.ResourceWithModifyPlan = (*serviceResource)(nil)
+
 func (r *serviceResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_service"
 }
@@ -54,10 +72,11 @@ func (r *serviceResource) Configure(_ context.Context, req resource.ConfigureReq
 
 	r.context = ctx
 	r.client = ctx.Client
+	r.api = ctx.API
 }
 
 // Schema returns the schema information for a server resource.
-func (r *serviceResource) Schema(_ context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *serviceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	disk := schema.SingleNestedAttribute{
 		Optional: true,
 		Attributes: map[string]schema.Attribute{
@@ -67,34 +86,74 @@ func (r *serviceResource) Schema(_ context.Context, req resource.SchemaRequest,
 		},
 	}
 
+	unknown := []planmodifier.String{stringplanmodifier.UseStateForUnknown()}
+	regionDescription := fmt.Sprintf("One of %s. See the `render_regions` data source.", utils.Join(utils.Regions()))
+	planDescription := fmt.Sprintf("One of %s.", utils.Join(utils.Plans()))
+	replace := []planmodifier.String{stringplanmodifier.UseStateForUnknown(), stringplanmodifier.RequiresReplace()}
+
+	native := schema.SingleNestedAttribute{
+		Optional: true,
+		Attributes: map[string]schema.Attribute{
+			"build_command": schema.StringAttribute{Optional: true},
+			"start_command": schema.StringAttribute{Optional: true},
+		},
+	}
+
+	pullRequestPreviewsEnabled := schema.BoolAttribute{
+		Optional:           true,
+		DeprecationMessage: "Use the `previews` block instead.",
+		Validators:         []validator.Bool{boolvalidator.ConflictsWith(path.MatchRoot("previews"))},
+	}
+
+	preDeployCommand := schema.StringAttribute{
+		Description: "Runs after the build and before the new version starts, e.g. to run database migrations. Works for both native and `docker` environments.",
+		Optional:    true,
+	}
+
 	resp.Schema = schema.Schema{
 		Description: `Provider for service resource`,
 		Attributes: map[string]schema.Attribute{
-			"id":          schema.StringAttribute{Computed: true},
+			"id":          schema.StringAttribute{Computed: true, PlanModifiers: unknown},
 			"name":        schema.StringAttribute{Required: true},
 			"type":        schema.StringAttribute{Required: true, PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
-			"branch":      schema.StringAttribute{Optional: true, Computed: true},
-			"auto_deploy": schema.BoolAttribute{Optional: true},
+			"branch":      schema.StringAttribute{Optional: true, Computed: true, PlanModifiers: unknown},
+			"auto_deploy": schema.BoolAttribute{Optional: true, Computed: true, PlanModifiers: []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()}},
 			"repo":        schema.StringAttribute{Required: true, PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
-			"owner":       schema.StringAttribute{Optional: true, Computed: true, PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+			"owner":       schema.StringAttribute{Optional: true, Computed: true, PlanModifiers: replace},
+
+			"root_directory": schema.StringAttribute{
+				Description: "The directory of the service in the repository, e.g. for monorepos. Commands run in it and only changes in it trigger an auto deploy, unless `build_filter` is set.",
+				Optional:    true,
+			},
+
+			"environment_id": schema.StringAttribute{
+				Description: "The ID of the project environment the service is in, see `render_project_environment`. Removing it moves the service out of the environment.",
+				Optional:    true,
+			},
 
 			"web_service_details": schema.SingleNestedAttribute{
 				Description: "Service details for `web_service` type services.",
 				Optional:    true,
 				Attributes: map[string]schema.Attribute{
 					"env":                           schema.StringAttribute{Required: true, PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
-					"region":                        schema.StringAttribute{Optional: true, Computed: true, PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
-					"plan":                          schema.StringAttribute{Optional: true, Computed: true},
-					"health_check_path":             schema.StringAttribute{Optional: true, Computed: true},
-					"pull_request_previews_enabled": schema.BoolAttribute{Optional: true},
-					"url":                           schema.StringAttribute{Computed: true},
-
-					"native": schema.SingleNestedAttribute{
-						Optional: true,
-						Attributes: map[string]schema.Attribute{
-							"build_command": schema.StringAttribute{Optional: true},
-							"start_command": schema.StringAttribute{Optional: true},
-						},
+					"region":                        schema.StringAttribute{Optional: true, Computed: true, PlanModifiers: replace, Validators: []validator.String{validators.Region()}, Description: regionDescription},
+					"plan":                          schema.StringAttribute{Optional: true, Computed: true, PlanModifiers: unknown, Validators: []validator.String{validators.Plan()}, Description: planDescription},
+					"health_check_path":             schema.StringAttribute{Optional: true, Computed: true, PlanModifiers: unknown},
+					"pull_request_previews_enabled": pullRequestPreviewsEnabled,
+					"pre_deploy_command":            preDeployCommand,
+					"url":                           schema.StringAttribute{Computed: true, PlanModifiers: unknown},
+					"native":                        native,
+
+					"max_shutdown_delay_seconds": schema.Int64Attribute{
+						Description:   "How long Render waits for an instance to exit after sending it `SIGTERM` during a deploy, from 1 to 300 seconds. Render defaults to 30.",
+						Optional:      true,
+						Computed:      true,
+						PlanModifiers: []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
+						Validators:    []validator.Int64{int64validator.Between(1, 300)},
+					},
+					"docker_command": schema.StringAttribute{
+						Description: "Overrides the `CMD` of the image. Only for services with env `docker`.",
+						Optional:    true,
 					},
 				},
 			},
@@ -105,8 +164,8 @@ func (r *serviceResource) Schema(_ context.Context, req resource.SchemaRequest,
 				Attributes: map[string]schema.Attribute{
 					"build_command":                 schema.StringAttribute{Optional: true},
 					"publish_path":                  schema.StringAttribute{Optional: true},
-					"pull_request_previews_enabled": schema.BoolAttribute{Optional: true},
-					"url":                           schema.StringAttribute{Computed: true},
+					"pull_request_previews_enabled": pullRequestPreviewsEnabled,
+					"url":                           schema.StringAttribute{Computed: true, PlanModifiers: unknown},
 				},
 			},
 
@@ -115,13 +174,71 @@ func (r *serviceResource) Schema(_ context.Context, req resource.SchemaRequest,
 				Optional:    true,
 				Attributes: map[string]schema.Attribute{
 					"env":                           schema.StringAttribute{Required: true, PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
-					"region":                        schema.StringAttribute{Optional: true, Computed: true, PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
-					"plan":                          schema.StringAttribute{Optional: true, Computed: true},
-					"pull_request_previews_enabled": schema.BoolAttribute{Optional: true},
-					"url":                           schema.StringAttribute{Computed: true},
+					"region":                        schema.StringAttribute{Optional: true, Computed: true, PlanModifiers: replace, Validators: []validator.String{validators.Region()}, Description: regionDescription},
+					"plan":                          schema.StringAttribute{Optional: true, Computed: true, PlanModifiers: unknown, Validators: []validator.String{validators.Plan()}, Description: planDescription},
+					"pull_request_previews_enabled": pullRequestPreviewsEnabled,
+					"pre_deploy_command":            preDeployCommand,
+					"url":                           schema.StringAttribute{Computed: true, PlanModifiers: unknown},
 					"disk":                          disk,
 				},
 			},
+
+			"background_worker_details": schema.SingleNestedAttribute{
+				Description: "Service details for `background_worker` type services.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"env":                schema.StringAttribute{Required: true, PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+					"region":             schema.StringAttribute{Optional: true, Computed: true, PlanModifiers: replace, Validators: []validator.String{validators.Region()}, Description: regionDescription},
+					"plan":               schema.StringAttribute{Optional: true, Computed: true, PlanModifiers: unknown, Validators: []validator.String{validators.Plan()}, Description: planDescription},
+					"pre_deploy_command": preDeployCommand,
+					"native":             native,
+					"disk":               disk,
+				},
+			},
+
+			"timeouts": timeouts.AttributesAll(ctx),
+		},
+
+		Blocks: map[string]schema.Block{
+			"previews": schema.SingleNestedBlock{
+				Description: "Preview instances of pull requests, for `web_service`, `private_service` and `static_site` services. `generation` is required.",
+				// Required attributes would be required without the block as well
+				Validators: []validator.Object{objectvalidator.AlsoRequires(path.MatchRelative().AtName("generation"))},
+				Attributes: map[string]schema.Attribute{
+					"generation": schema.StringAttribute{
+						Description: "One of `off`, `manual` (only for pull requests with `[render preview]` in the title) or `automatic`.",
+						Optional:    true,
+						Validators:  []validator.String{stringvalidator.OneOf("off", "manual", "automatic")},
+					},
+					"expire_after_days": schema.Int64Attribute{
+						Description: "Delete previews after this many days without a new commit.",
+						Optional:    true,
+						Validators:  []validator.Int64{int64validator.AtLeast(1)},
+					},
+					"plan": schema.StringAttribute{
+						Description: "The plan of preview instances, if different from the service. " + planDescription,
+						Optional:    true,
+						Validators:  []validator.String{validators.Plan()},
+					},
+				},
+			},
+			"build_filter": schema.SingleNestedBlock{
+				Description: "Glob patterns, relative to the repository root, of the files whose changes trigger an auto deploy.",
+				Attributes: map[string]schema.Attribute{
+					"paths": schema.ListAttribute{
+						Description: "Only changes to matching files trigger a deploy, e.g. `services/api/**`.",
+						Optional:    true,
+						ElementType: basetypes.StringType{},
+						Validators:  []validator.List{listvalidator.ValueStringsAre(validators.Glob())},
+					},
+					"ignored_paths": schema.ListAttribute{
+						Description: "Changes to matching files never trigger a deploy, e.g. `**/*.md`.",
+						Optional:    true,
+						ElementType: basetypes.StringType{},
+						Validators:  []validator.List{listvalidator.ValueStringsAre(validators.Glob())},
+					},
+				},
+			},
 		},
 	}
 }
@@ -131,6 +248,9 @@ func (r *serviceResource) Create(ctx context.Context, req resource.CreateRequest
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 
+	ctx, cancel := withTimeout(ctx, plan.Timeouts.Create, &resp.Diagnostics)
+	defer cancel()
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -151,7 +271,7 @@ func (r *serviceResource) Create(ctx context.Context, req resource.CreateRequest
 
 	tflog.Debug(ctx, "creating service", utils.ToJson(post))
 
-	response, err := r.client.CreateServiceWithResponse(ctx, *post)
+	response, err := r.api.CreateServiceWithResponse(ctx, *post)
 
 	if err != nil {
 		resp.Diagnostics.AddError("failed to create service", err.Error())
@@ -172,6 +292,15 @@ func (r *serviceResource) Create(ctx context.Context, req resource.CreateRequest
 
 	result := plan.FromResponse(*s)
 
+	if !plan.EnvironmentID.IsNull() {
+		// The service exists either way, so it is kept in state, tainted when it couldn't be moved
+		if err := r.moveService(ctx, result.ID.ValueString(), "", plan.EnvironmentID.ValueString()); err != nil {
+			resp.Diagnostics.AddError("failed to move service to environment", err.Error())
+		} else {
+			result.EnvironmentID = plan.EnvironmentID
+		}
+	}
+
 	resp.State.Set(ctx, result)
 }
 
@@ -180,11 +309,14 @@ func (r *serviceResource) Read(ctx context.Context, req resource.ReadRequest, re
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
+	ctx, cancel := withTimeout(ctx, state.Timeouts.Read, &resp.Diagnostics)
+	defer cancel()
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	s, err := r.client.GetServiceWithResponse(ctx, state.ID.ValueString())
+	s, err := r.api.GetServiceWithResponse(ctx, state.ID.ValueString())
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -197,6 +329,27 @@ func (r *serviceResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
+	if s.StatusCode() == http.StatusNotFound {
+		tflog.Warn(ctx, "service not found, removing it from state", map[string]interface{}{
+			"service_id": state.ID.ValueString(),
+		})
+
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if s.StatusCode() != http.StatusOK {
+		resp.Diagnostics.AddError(
+			"Error reading service",
+			fmt.Sprintf("Could not read service %s: %s %s",
+				state.ID.ValueString(),
+				s.Status(),
+				string(s.Body),
+			),
+		)
+		return
+	}
+
 	result := state.FromResponse(*s.JSON200)
 
 	tflog.Trace(ctx, "read service", map[string]interface{}{
@@ -216,6 +369,9 @@ func (r *serviceResource) Update(ctx context.Context, req resource.UpdateRequest
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
+	ctx, cancel := withTimeout(ctx, plan.Timeouts.Update, &resp.Diagnostics)
+	defer cancel()
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -227,7 +383,7 @@ func (r *serviceResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
-	response, err := r.client.UpdateServiceWithResponse(ctx, state.ID.ValueString(), *patch)
+	response, err := r.api.UpdateServiceWithResponse(ctx, state.ID.ValueString(), *patch)
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -241,8 +397,30 @@ func (r *serviceResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
+	if response.StatusCode() != http.StatusOK {
+		resp.Diagnostics.AddError(
+			"Error updating service",
+			fmt.Sprintf(
+				"Could not update service %s: %s %s",
+				state.ID.ValueString(),
+				response.Status(),
+				string(response.Body),
+			),
+		)
+		return
+	}
+
 	result := plan.FromResponse(*response.JSON200)
 
+	if !plan.EnvironmentID.Equal(state.EnvironmentID) {
+		if err := r.moveService(ctx, state.ID.ValueString(), state.EnvironmentID.ValueString(), plan.EnvironmentID.ValueString()); err != nil {
+			resp.Diagnostics.AddError("failed to move service to environment", err.Error())
+			return
+		}
+
+		result.EnvironmentID = plan.EnvironmentID
+	}
+
 	tflog.Debug(ctx, "updated service: "+response.Status(), map[string]interface{}{
 		"service_id": result.ID.ValueString(),
 		"service":    response.JSON200,
@@ -259,13 +437,18 @@ func (r *serviceResource) Update(ctx context.Context, req resource.UpdateRequest
 
 func (r *serviceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var state models.Service
+
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
+
+	ctx, cancel := withTimeout(ctx, state.Timeouts.Delete, &resp.Diagnostics)
+	defer cancel()
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	_, err := r.client.DeleteService(ctx, state.ID.ValueString())
+	response, err := r.client.DeleteServiceWithResponse(ctx, state.ID.ValueString())
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -279,15 +462,150 @@ func (r *serviceResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
+	switch response.StatusCode() {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+	default:
+		resp.Diagnostics.AddError(
+			"Error deleting service",
+			fmt.Sprintf(
+				"Could not delete service %s: %s %s",
+				state.ID.ValueString(),
+				response.Status(),
+				string(response.Body),
+			),
+		)
+		return
+	}
+
 	tflog.Trace(ctx, "deleted service", map[string]interface{}{
 		"service_id": state.ID.ValueString(),
 	})
 }
 
+func (r *serviceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// ModifyPlan defaults the owner of new services to the owner resolved by the provider, and
+// applies the provider `defaults` block. This can't be done by schema plan modifiers, as
+// schemas are built before the provider is configured.
+func (r *serviceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.context == nil {
+		return
+	}
+
+	defaults := r.context.Defaults
+
+	modifyPlanString(ctx, req, resp, path.Root("owner"), modifiers.OwnerDefault(r.context.Owner))
+
+	if defaults.Branch != "" {
+		modifyPlanString(ctx, req, resp, path.Root("branch"), modifiers.StringDefaultValue(defaults.Branch))
+	}
+
+	if defaults.AutoDeploy != nil {
+		modifyPlanBool(ctx, req, resp, path.Root("auto_deploy"), modifiers.BoolDefaultValue(*defaults.AutoDeploy))
+	}
+
+	for _, name := range []string{"web_service_details", "private_service_details", "background_worker_details"} {
+		var details basetypes.ObjectValue
+
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root(name), &details)...)
+
+		if details.IsNull() || details.IsUnknown() {
+			continue
+		}
+
+		if defaults.Region != "" {
+			modifyPlanString(ctx, req, resp, path.Root(name).AtName("region"), modifiers.StringDefaultValue(defaults.Region))
+		}
+
+		if defaults.Plan != "" {
+			modifyPlanString(ctx, req, resp, path.Root(name).AtName("plan"), modifiers.StringDefaultValue(defaults.Plan))
+		}
+	}
+}
+
+func modifyPlanString(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, p path.Path, modifier planmodifier.String) {
+	request := planmodifier.StringRequest{Path: p}
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, p, &request.ConfigValue)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, p, &request.PlanValue)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result := planmodifier.StringResponse{PlanValue: request.PlanValue}
+
+	modifier.PlanModifyString(ctx, request, &result)
+
+	resp.Diagnostics.Append(result.Diagnostics...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, p, result.PlanValue)...)
+}
+
+func modifyPlanBool(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, p path.Path, modifier planmodifier.Bool) {
+	request := planmodifier.BoolRequest{Path: p}
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, p, &request.ConfigValue)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, p, &request.PlanValue)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result := planmodifier.BoolResponse{PlanValue: request.PlanValue}
+
+	modifier.PlanModifyBool(ctx, request, &result)
+
+	resp.Diagnostics.Append(result.Diagnostics...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, p, result.PlanValue)...)
+}
+
+// moveService moves a service out of the environment from and into the environment to,
+// either of which can be empty.
+func (r *serviceResource) moveService(ctx context.Context, serviceId string, from string, to string) error {
+	tflog.Debug(ctx, "moving service", map[string]interface{}{
+		"service_id": serviceId,
+		"from":       from,
+		"to":         to,
+	})
+
+	if from != "" {
+		response, err := r.api.RemoveResourcesFromEnvironmentWithResponse(ctx, from, []string{serviceId})
+
+		if err != nil {
+			return err
+		}
+
+		switch response.StatusCode() {
+		case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+		default:
+			return fmt.Errorf("%s %s", response.Status(), string(response.Body))
+		}
+	}
+
+	if to == "" {
+		return nil
+	}
+
+	response, err := r.api.AddResourcesToEnvironmentWithResponse(ctx, to, []string{serviceId})
+
+	if err != nil {
+		return err
+	}
+
+	switch response.StatusCode() {
+	case http.StatusOK, http.StatusNoContent:
+		return nil
+	default:
+		return fmt.Errorf("%s %s", response.Status(), string(response.Body))
+	}
+}
+
 func getOwner(c *types.Context, plan models.Service) (string, error) {
-	if plan.Owner.IsNull() || plan.Owner.ValueString() == "" {
+	if plan.Owner.IsNull() || plan.Owner.IsUnknown() || plan.Owner.ValueString() == "" {
 		if c.Owner == nil {
-			return "", fmt.Errorf("'owner' is required if a global email is not set")
+			return "", fmt.Errorf("'owner' is required if the provider has no 'owner_id', 'owner_name' or 'email'")
 		}
 
 		return c.Owner.Id, nil
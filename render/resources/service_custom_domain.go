@@ -3,16 +3,26 @@ package resources
 import (
 	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/jackall3n/render-go"
 	"github.com/jackall3n/terraform-provider-render/render/models"
 	"github.com/jackall3n/terraform-provider-render/render/types"
 	"github.com/jackall3n/terraform-provider-render/render/utils"
-	"io"
+	"net/http"
+	"strings"
+	"time"
 )
 
+// verificationInterval is how often `wait_for_verification` polls the verify endpoint.
+var verificationInterval = 10 * time.Second
+
 func ServiceCustomDomainResource() resource.Resource {
 	return &serviceCustomDomainResource{}
 }
@@ -22,6 +32,8 @@ type serviceCustomDomainResource struct {
 	context *types.Context
 }
 
+var _ resource.ResourceWithImportState = (*serviceCustomDomainResource)(nil)
+
 func (r *serviceCustomDomainResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_service_custom_domain"
 }
@@ -45,12 +57,40 @@ func (r *serviceCustomDomainResource) Configure(_ context.Context, req resource.
 	r.client = ctx.Client
 }
 
-func (r *serviceCustomDomainResource) Schema(_ context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *serviceCustomDomainResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	unknown := []planmodifier.String{stringplanmodifier.UseStateForUnknown()}
+
 	resp.Schema = schema.Schema{
 		Description: `Provider for service custom domain resource`,
 		Attributes: map[string]schema.Attribute{
-			"service_id":  schema.StringAttribute{Required: true},
-			"domain_name": schema.StringAttribute{Required: true},
+			"id":          schema.StringAttribute{Computed: true, PlanModifiers: unknown},
+			"service_id":  schema.StringAttribute{Required: true, PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+			"domain_name": schema.StringAttribute{Required: true, PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+
+			"domain_type":         schema.StringAttribute{Computed: true, PlanModifiers: unknown, Description: "Either `apex` or `subdomain`."},
+			"verification_status": schema.StringAttribute{Computed: true, Description: "Either `verified` or `unverified`."},
+			"redirect_for_name":   schema.StringAttribute{Computed: true, PlanModifiers: unknown, Description: "The domain this domain redirects to, e.g. `example.com` for `www.example.com`."},
+			"public_suffix":       schema.StringAttribute{Computed: true, PlanModifiers: unknown},
+
+			"dns_records": schema.ListNestedAttribute{
+				Description:   "DNS records that have to exist for the domain to verify.",
+				Computed:      true,
+				PlanModifiers: []planmodifier.List{listplanmodifier.UseStateForUnknown()},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type":  schema.StringAttribute{Computed: true},
+						"name":  schema.StringAttribute{Computed: true},
+						"value": schema.StringAttribute{Computed: true},
+					},
+				},
+			},
+
+			"wait_for_verification": schema.BoolAttribute{
+				Description: "Trigger DNS verification after creation and wait until the domain is verified.",
+				Optional:    true,
+			},
+
+			"timeouts": timeouts.AttributesAll(ctx),
 		},
 	}
 }
@@ -61,6 +101,9 @@ func (r *serviceCustomDomainResource) Create(ctx context.Context, req resource.C
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 
+	ctx, cancel := withTimeout(ctx, plan.Timeouts.Create, &resp.Diagnostics)
+	defer cancel()
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -74,26 +117,61 @@ func (r *serviceCustomDomainResource) Create(ctx context.Context, req resource.C
 		"domain_name": customDomainJSONBody,
 	}))
 
-	response, err := r.client.CreateCustomDomain(ctx, plan.ServiceID.ValueString(), customDomainJSONBody)
+	response, err := r.client.CreateCustomDomainWithResponse(ctx, plan.ServiceID.ValueString(), customDomainJSONBody)
 
-	bytes, _ := io.ReadAll(response.Body)
-
-	if response.StatusCode != 201 {
+	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to create custom domain",
 			fmt.Sprintf("Could not create custom domain %s, unexpected error: %s",
-				response.Status,
+				plan.DomainName.ValueString(),
 				err.Error(),
 			),
 		)
 		return
 	}
 
-	tflog.Debug(ctx, "Created custom domain "+response.Status, map[string]interface{}{
-		"r": string(bytes),
+	if response.StatusCode() != http.StatusCreated || response.JSON201 == nil || len(*response.JSON201) == 0 {
+		resp.Diagnostics.AddError(
+			"Failed to create custom domain",
+			fmt.Sprintf("Could not create custom domain %s: %s %s",
+				plan.DomainName.ValueString(),
+				response.Status(),
+				string(response.Body),
+			),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "Created custom domain "+response.Status(), map[string]interface{}{
+		"r": string(response.Body),
 	})
 
-	resp.State.Set(ctx, plan)
+	host, err := r.getServiceHost(ctx, plan.ServiceID.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("failed to get service", err.Error())
+		return
+	}
+
+	result := plan.FromResponse((*response.JSON201)[0], host)
+
+	if plan.WaitForVerification.ValueBool() && !result.IsVerified() {
+		verified, err := r.waitForVerification(ctx, result, host)
+
+		if err != nil {
+			// The domain exists, keep it in state so it is not orphaned.
+			resp.Diagnostics.Append(resp.State.Set(ctx, result)...)
+			resp.Diagnostics.AddError(
+				"Custom domain was not verified",
+				fmt.Sprintf("Custom domain %s was created but could not be verified: %s", result.DomainName.ValueString(), err.Error()),
+			)
+			return
+		}
+
+		result = verified
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, result)...)
 }
 
 func (r *serviceCustomDomainResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -102,6 +180,9 @@ func (r *serviceCustomDomainResource) Read(ctx context.Context, req resource.Rea
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 
+	ctx, cancel := withTimeout(ctx, state.Timeouts.Read, &resp.Diagnostics)
+	defer cancel()
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -124,7 +205,36 @@ func (r *serviceCustomDomainResource) Read(ctx context.Context, req resource.Rea
 		return
 	}
 
-	result := state.FromResponse(*s.JSON200)
+	if s.StatusCode() == http.StatusNotFound {
+		tflog.Warn(ctx, "custom domain not found, removing it from state", map[string]interface{}{
+			"service_id":    state.ServiceID.ValueString(),
+			"custom_domain": state.DomainName.ValueString(),
+		})
+
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if s.StatusCode() != http.StatusOK {
+		resp.Diagnostics.AddError(
+			"Failed to read custom domain",
+			fmt.Sprintf("Could not read custom domain %s: %s %s",
+				state.DomainName.ValueString(),
+				s.Status(),
+				string(s.Body),
+			),
+		)
+		return
+	}
+
+	host, err := r.getServiceHost(ctx, state.ServiceID.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("failed to get service", err.Error())
+		return
+	}
+
+	result := state.FromResponse(*s.JSON200, host)
 
 	tflog.Trace(ctx, "Read custom domain", map[string]interface{}{
 		"service_id":    result.ServiceID.ValueString(),
@@ -139,55 +249,73 @@ func (r *serviceCustomDomainResource) Read(ctx context.Context, req resource.Rea
 }
 
 func (r *serviceCustomDomainResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan, state models.ServiceCustomDomain
+	var plan models.ServiceCustomDomain
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
-	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	ctx, cancel := withTimeout(ctx, plan.Timeouts.Update, &resp.Diagnostics)
+	defer cancel()
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	tflog.Debug(ctx, "updating custom domain")
+	// Both `service_id` and `domain_name` force a replacement, so only
+	// `wait_for_verification` can change here.
+	tflog.Debug(ctx, "updating custom domain", map[string]interface{}{
+		"service_id":    plan.ServiceID.ValueString(),
+		"custom_domain": plan.DomainName.ValueString(),
+	})
 
-	_, deleteErr := r.client.DeleteCustomDomainWithResponse(ctx, state.ServiceID.ValueString(), state.DomainName.ValueString())
+	response, err := r.client.GetCustomDomainWithResponse(ctx, plan.ServiceID.ValueString(), plan.DomainName.ValueString())
 
-	if deleteErr != nil {
-		resp.Diagnostics.AddError("failed to update custom domain", deleteErr.Error())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating custom domain",
+			fmt.Sprintf("Could not read custom domain %s, unexpected error: %s",
+				plan.DomainName.ValueString(),
+				err,
+			),
+		)
 		return
 	}
 
-	customDomainJSONBody := render.CreateCustomDomainJSONRequestBody{
-		Name: plan.DomainName.ValueString(),
-	}
-
-	createResponse, createErr := r.client.CreateCustomDomainWithResponse(ctx, state.ServiceID.ValueString(), customDomainJSONBody)
-
-	if createErr != nil {
+	if response.StatusCode() != http.StatusOK {
 		resp.Diagnostics.AddError(
 			"Error updating custom domain",
-			fmt.Sprintf(
-				"Could not update custom domain %s, unexpected error: %s",
-				state.DomainName.ValueString(),
-				createErr,
+			fmt.Sprintf("Could not read custom domain %s: %s %s",
+				plan.DomainName.ValueString(),
+				response.Status(),
+				string(response.Body),
 			),
 		)
 		return
 	}
 
-	arrayOfSingleDomain := *createResponse.JSON201
-	result := arrayOfSingleDomain[0]
+	host, err := r.getServiceHost(ctx, plan.ServiceID.ValueString())
 
-	tflog.Debug(ctx, "Updated service: "+createResponse.Status(), map[string]interface{}{
-		"service_id":  state.ServiceID.ValueString(),
-		"domain_name": result.Name,
-	})
+	if err != nil {
+		resp.Diagnostics.AddError("failed to get service", err.Error())
+		return
+	}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, state.FromResponse(result))...)
+	result := plan.FromResponse(*response.JSON200, host)
 
-	if resp.Diagnostics.HasError() {
-		return
+	if plan.WaitForVerification.ValueBool() && !result.IsVerified() {
+		verified, err := r.waitForVerification(ctx, result, host)
+
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Custom domain was not verified",
+				fmt.Sprintf("Custom domain %s could not be verified: %s", result.DomainName.ValueString(), err.Error()),
+			)
+			return
+		}
+
+		result = verified
 	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, result)...)
 }
 
 func (r *serviceCustomDomainResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -196,6 +324,9 @@ func (r *serviceCustomDomainResource) Delete(ctx context.Context, req resource.D
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 
+	ctx, cancel := withTimeout(ctx, state.Timeouts.Delete, &resp.Diagnostics)
+	defer cancel()
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -209,12 +340,104 @@ func (r *serviceCustomDomainResource) Delete(ctx context.Context, req resource.D
 		return
 	}
 
-	s := response.JSON400
+	switch response.StatusCode() {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+	default:
+		resp.Diagnostics.AddError("failed to delete custom domain", fmt.Sprintf("%s %s", response.Status(), string(response.Body)))
+		return
+	}
 
 	tflog.Debug(ctx, "Deleted custom domain: "+response.Status(), map[string]interface{}{
-		"s": s,
 		"r": string(response.Body),
 	})
+}
+
+// ImportState imports a custom domain from an ID of the form `<service_id>:<domain_name>`.
+func (r *serviceCustomDomainResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.SplitN(req.ID, ":", 2)
+
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected import identifier",
+			fmt.Sprintf("Expected an identifier of the form <service_id>:<domain_name>, got: %s", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("service_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("domain_name"), parts[1])...)
+}
+
+// waitForVerification asks Render to check the DNS records of the domain until it is verified
+// or the deadline of ctx, set from `timeouts`, passes.
+func (r *serviceCustomDomainResource) waitForVerification(ctx context.Context, domain models.ServiceCustomDomain, host string) (models.ServiceCustomDomain, error) {
+	serviceId := domain.ServiceID.ValueString()
+	name := domain.DomainName.ValueString()
+
+	for {
+		tflog.Debug(ctx, "verifying custom domain", map[string]interface{}{
+			"service_id":    serviceId,
+			"custom_domain": name,
+		})
+
+		verify, err := r.client.RefreshCustomDomainWithResponse(ctx, serviceId, name)
+
+		if err != nil {
+			return domain, verificationError(ctx, err)
+		}
+
+		if verify.StatusCode() >= http.StatusBadRequest {
+			return domain, fmt.Errorf("%s %s", verify.Status(), string(verify.Body))
+		}
+
+		response, err := r.client.GetCustomDomainWithResponse(ctx, serviceId, name)
+
+		if err != nil {
+			return domain, verificationError(ctx, err)
+		}
+
+		if response.StatusCode() != http.StatusOK {
+			return domain, fmt.Errorf("%s %s", response.Status(), string(response.Body))
+		}
+
+		domain = domain.FromResponse(*response.JSON200, host)
+
+		if domain.IsVerified() {
+			return domain, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return domain, verificationError(ctx, ctx.Err())
+		case <-time.After(verificationInterval):
+		}
+	}
+}
+
+// verificationError explains err as a timeout if the deadline of ctx passed, even when it passed during a request.
+func verificationError(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return fmt.Errorf("timed out waiting for verification, check the records in `dns_records` or raise `timeouts`: %s", ctx.Err())
+	}
+
+	return err
+}
+
+// getServiceHost returns the `onrender.com` host name of a service.
+func (r *serviceCustomDomainResource) getServiceHost(ctx context.Context, serviceId string) (string, error) {
+	response, err := r.client.GetServiceWithResponse(ctx, serviceId)
+
+	if err != nil {
+		return "", err
+	}
+
+	if response.StatusCode() != http.StatusOK {
+		return "", fmt.Errorf("%s %s", response.Status(), string(response.Body))
+	}
+
+	if response.JSON200.Slug == nil {
+		return "", nil
+	}
 
-	resp.State.Set(ctx, state)
+	return *response.JSON200.Slug + ".onrender.com", nil
 }
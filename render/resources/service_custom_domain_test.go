@@ -0,0 +1,150 @@
+package resources
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jackall3n/render-go"
+	"github.com/jackall3n/terraform-provider-render/internal/fakerender"
+	"github.com/jackall3n/terraform-provider-render/render/api"
+	"github.com/jackall3n/terraform-provider-render/render/models"
+)
+
+// newFakeStaticSite starts a fake Render API with one static site, verifying custom domains on
+// their polls-th verify request.
+func newFakeStaticSite(t *testing.T, polls int) (*fakerender.Server, string) {
+	t.Helper()
+
+	interval := verificationInterval
+	verificationInterval = time.Millisecond
+	t.Cleanup(func() { verificationInterval = interval })
+
+	server := fakerender.New()
+	t.Cleanup(server.Close)
+
+	server.SetVerifyAfter(polls)
+
+	id, err := server.AddService(api.ServicePOST{ServicePOST: render.ServicePOST{Name: "site", OwnerId: "usr-1", Repo: "repo", Type: render.StaticSite}})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return server, id
+}
+
+// createCustomDomain creates a custom domain that waits for verification for at most timeout.
+func createCustomDomain(t *testing.T, server *fakerender.Server, serviceId string, timeout string) (models.ServiceCustomDomain, resource.CreateResponse) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	client, err := render.NewClientWithResponses(server.URL)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := &serviceCustomDomainResource{client: client}
+
+	schemaResponse := resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResponse)
+
+	plan := tfsdk.Plan{Schema: schemaResponse.Schema}
+
+	diags := plan.Set(ctx, models.ServiceCustomDomain{
+		ID:                  types.StringUnknown(),
+		ServiceID:           types.StringValue(serviceId),
+		DomainName:          types.StringValue("www.example.com"),
+		DomainType:          types.StringUnknown(),
+		VerificationStatus:  types.StringUnknown(),
+		RedirectForName:     types.StringUnknown(),
+		PublicSuffix:        types.StringUnknown(),
+		DNSRecords:          types.ListUnknown(types.ObjectType{AttrTypes: models.DNSRecordAttributeTypes}),
+		WaitForVerification: types.BoolValue(true),
+		Timeouts: timeouts.Value{Object: types.ObjectValueMust(
+			map[string]attr.Type{"create": types.StringType, "read": types.StringType, "update": types.StringType, "delete": types.StringType},
+			map[string]attr.Value{"create": types.StringValue(timeout), "read": types.StringNull(), "update": types.StringNull(), "delete": types.StringNull()},
+		)},
+	})
+
+	if diags.HasError() {
+		t.Fatalf("failed to set plan: %v", diags)
+	}
+
+	resp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResponse.Schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, &resp)
+
+	var state models.ServiceCustomDomain
+
+	if !resp.State.Raw.IsNull() {
+		if diags := resp.State.Get(ctx, &state); diags.HasError() {
+			t.Fatalf("failed to get state: %v", diags)
+		}
+	}
+
+	return state, resp
+}
+
+// verifyRequests counts the verify requests made to server.
+func verifyRequests(server *fakerender.Server) int {
+	count := 0
+
+	for _, request := range server.Requests() {
+		if strings.HasSuffix(request, "/verify") {
+			count++
+		}
+	}
+
+	return count
+}
+
+func TestServiceCustomDomainCreateWaitsForVerification(t *testing.T) {
+	server, id := newFakeStaticSite(t, 3)
+
+	state, resp := createCustomDomain(t, server, id, "1m")
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	if !state.IsVerified() {
+		t.Errorf("expected the domain to be verified, got %s", state.VerificationStatus)
+	}
+
+	if polls := verifyRequests(server); polls != 3 {
+		t.Errorf("expected 3 verify requests, got %d", polls)
+	}
+}
+
+func TestServiceCustomDomainCreateVerificationTimeout(t *testing.T) {
+	server, id := newFakeStaticSite(t, fakerender.VerifyNever)
+
+	state, resp := createCustomDomain(t, server, id, "100ms")
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error once the create timeout passed")
+	}
+
+	diagnostic := resp.Diagnostics.Errors()[0]
+
+	if diagnostic.Summary() != "Custom domain was not verified" || !strings.Contains(diagnostic.Detail(), "timed out waiting for verification") {
+		t.Errorf("unexpected diagnostic %s: %s", diagnostic.Summary(), diagnostic.Detail())
+	}
+
+	// The domain was created, it is kept in state so that it isn't orphaned.
+	if state.ID.ValueString() == "" || state.IsVerified() {
+		t.Errorf("expected the unverified domain in state, got %+v", state)
+	}
+
+	if polls := verifyRequests(server); polls < 2 {
+		t.Errorf("expected verification to be polled until the timeout, got %d verify requests", polls)
+	}
+}
@@ -3,8 +3,11 @@ package resources
 import (
 	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/jackall3n/render-go"
 	"github.com/jackall3n/terraform-provider-render/render/models"
@@ -22,6 +25,9 @@ type serviceEnvironmentResource struct {
 	context *types.Context
 }
 
+var _ resource.ResourceWithUpgradeState = (*serviceEnvironmentResource)(nil)
+var _ resource.ResourceWithImportState = (*serviceEnvironmentResource)(nil)
+
 func (r *serviceEnvironmentResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_service_environment"
 }
@@ -47,23 +53,85 @@ func (r *serviceEnvironmentResource) Configure(_ context.Context, req resource.C
 }
 
 // Schema returns the schema information for a server resource.
-func (r *serviceEnvironmentResource) Schema(_ context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *serviceEnvironmentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: `Provider for service environment resource`,
+		Version:     1,
 		Attributes: map[string]schema.Attribute{
 			"service": schema.StringAttribute{Required: true},
 
-			"variables": schema.ListNestedAttribute{
-				Description: "Service environment variable",
+			"variables": schema.MapNestedAttribute{
+				Description: "Service environment variables, keyed by variable name.",
 				Required:    true,
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
-						"key":       schema.StringAttribute{Required: true},
-						"value":     schema.StringAttribute{Optional: true},
+						"value":     schema.StringAttribute{Optional: true, Sensitive: true},
 						"generated": schema.BoolAttribute{Optional: true},
 					},
 				},
 			},
+
+			"generated_values":    generatedValuesAttribute,
+			"sensitive_variables": sensitiveVariablesAttribute,
+
+			"retain_on_destroy": schema.BoolAttribute{
+				Description: "Keep the variables on the service when this resource is destroyed.",
+				Optional:    true,
+			},
+
+			"timeouts": timeouts.AttributesAll(ctx),
+		},
+	}
+}
+
+var generatedValuesAttribute = schema.MapAttribute{
+	Description: "Values Render generated for variables with `generated = true`, keyed by variable name. A value is only generated again when its variable is removed and added back.",
+	Computed:    true,
+	Sensitive:   true,
+	ElementType: basetypes.StringType{},
+}
+
+var sensitiveVariablesAttribute = schema.MapAttribute{
+	Description: "Variables that are set once and never read back, so values rotated outside of Terraform are kept. Changing a value here still updates it.",
+	Optional:    true,
+	Sensitive:   true,
+	ElementType: basetypes.StringType{},
+}
+
+// UpgradeState migrates state from the list based `variables` of version 0.
+func (r *serviceEnvironmentResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"service": schema.StringAttribute{Required: true},
+
+					"variables": schema.ListNestedAttribute{
+						Required: true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"key":       schema.StringAttribute{Required: true},
+								"value":     schema.StringAttribute{Optional: true, Sensitive: true},
+								"generated": schema.BoolAttribute{Optional: true},
+							},
+						},
+					},
+
+					"generated_values":    generatedValuesAttribute,
+					"sensitive_variables": sensitiveVariablesAttribute,
+				},
+			},
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior models.ServiceEnvironmentV0
+
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, prior.Upgrade())...)
+			},
 		},
 	}
 }
@@ -74,26 +142,25 @@ func (r *serviceEnvironmentResource) Create(ctx context.Context, req resource.Cr
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 
+	ctx, cancel := withTimeout(ctx, plan.Timeouts.Create, &resp.Diagnostics)
+	defer cancel()
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	var variables []render.EnvVarsPATCH_Item
-
-	for _, i := range plan.Variables {
-		item, err := i.ToEnvVarsItemPATCH()
+	variables, err := plan.ToEnvVarsPATCH(nil)
 
-		if err != nil {
-			resp.Diagnostics.AddError("failed to convert to item", err.Error())
-			return
-		}
-
-		variables = append(variables, *item)
+	if err != nil {
+		resp.Diagnostics.AddError("failed to convert to item", err.Error())
+		return
 	}
 
-	tflog.Debug(ctx, "setting service variables", utils.ToJson(map[string]interface{}{
-		"variables": variables,
-	}))
+	// Values are sensitive, only log how many variables are sent.
+	tflog.Debug(ctx, "setting service variables", map[string]interface{}{
+		"service": plan.Service.ValueString(),
+		"count":   len(variables),
+	})
 
 	response, err := r.client.UpdateEnvVarsForServiceWithResponse(ctx, plan.Service.ValueString(), variables)
 
@@ -119,16 +186,9 @@ func (r *serviceEnvironmentResource) Create(ctx context.Context, req resource.Cr
 		return
 	}
 
-	s := response.JSON200
-
-	tflog.Debug(ctx, "Update service env vars: "+response.Status(), map[string]interface{}{
-		"s": s,
-		"r": string(response.Body),
-	})
+	tflog.Debug(ctx, "Update service env vars: "+response.Status())
 
-	//result := plan.FromResponse(*s)
-
-	resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan.WithGeneratedValues(envVarsFromResponse(response)))...)
 }
 
 func (r *serviceEnvironmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -137,6 +197,9 @@ func (r *serviceEnvironmentResource) Read(ctx context.Context, req resource.Read
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 
+	ctx, cancel := withTimeout(ctx, state.Timeouts.Read, &resp.Diagnostics)
+	defer cancel()
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -145,44 +208,34 @@ func (r *serviceEnvironmentResource) Read(ctx context.Context, req resource.Read
 		"service": state.Service.ValueString(),
 	})
 
-	response, err := r.client.GetEnvVarsForServiceWithResponse(ctx, state.Service.ValueString(), &render.GetEnvVarsForServiceParams{})
+	envVars, status, err := r.getEnvVars(ctx, state.Service.ValueString())
 
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error reading service variables",
-			fmt.Sprintf("Could not read service variables %s, unexpected error: %s",
-				state.Service.ValueString(),
-				err,
-			),
-		)
+	if status == http.StatusNotFound {
+		tflog.Warn(ctx, "service not found, removing variables from state", map[string]interface{}{
+			"service": state.Service.ValueString(),
+		})
 
+		resp.State.RemoveResource(ctx)
 		return
 	}
 
-	if response.StatusCode() != http.StatusOK {
+	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading service variables",
-			fmt.Sprintf("Could not read service variables %s %s, unexpected error: %s",
-				response.Status(),
+			fmt.Sprintf("Could not read service variables %s, unexpected error: %s",
 				state.Service.ValueString(),
-				string(response.Body),
+				err,
 			),
 		)
 
 		return
 	}
 
-	var variables []models.ServiceEnvironmentVariable
-
-	for _, item := range *response.JSON200 {
-		variables = append(variables, models.ServiceEnvironmentVariable{}.FromResponse(*item.EnvVar))
-	}
-
 	tflog.Trace(ctx, "read service variables", map[string]interface{}{
-		"variables": variables,
+		"count": len(envVars),
 	})
 
-	diags = resp.State.Set(ctx, state.FromResponse(variables))
+	diags = resp.State.Set(ctx, state.FromResponse(envVars))
 
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -191,31 +244,54 @@ func (r *serviceEnvironmentResource) Read(ctx context.Context, req resource.Read
 }
 
 func (r *serviceEnvironmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan models.ServiceEnvironment
+	var plan, state models.ServiceEnvironment
 
-	diags := req.Plan.Get(ctx, &plan)
-	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	ctx, cancel := withTimeout(ctx, plan.Timeouts.Update, &resp.Diagnostics)
+	defer cancel()
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	variables := render.UpdateEnvVarsForServiceJSONRequestBody{}
+	// The whole set of variables is replaced, so sensitive variables that were not
+	// changed in the configuration and generated variables that already exist are
+	// sent with the value Render currently has. Imported state doesn't know which
+	// variables were generated, so the plan decides.
+	current := map[string]string{}
+	generated := plan.GeneratedKeys()
 
-	for _, i := range plan.Variables {
-		item, err := i.ToEnvVarsItemPATCH()
+	if len(plan.SensitiveVariables) > 0 || len(generated) > 0 {
+		envVars, _, err := r.getEnvVars(ctx, plan.Service.ValueString())
 
 		if err != nil {
-			resp.Diagnostics.AddError("failed to convert to item", err.Error())
+			resp.Diagnostics.AddError("failed to read service variables", err.Error())
 			return
 		}
 
-		variables = append(variables, *item)
+		for _, envVar := range envVars {
+			previous, ok := state.SensitiveVariables[envVar.Key]
+
+			if (ok && previous.Equal(plan.SensitiveVariables[envVar.Key])) || generated[envVar.Key] {
+				current[envVar.Key] = envVar.Value
+			}
+		}
+	}
+
+	variables, err := plan.ToEnvVarsPATCH(current)
+
+	if err != nil {
+		resp.Diagnostics.AddError("failed to convert to item", err.Error())
+		return
 	}
 
-	tflog.Debug(ctx, "setting service variables", utils.ToJson(map[string]interface{}{
-		"variables": variables,
-	}))
+	// Values are sensitive, only log how many variables are sent.
+	tflog.Debug(ctx, "setting service variables", map[string]interface{}{
+		"service": plan.Service.ValueString(),
+		"count":   len(variables),
+	})
 
 	response, err := r.client.UpdateEnvVarsForServiceWithResponse(ctx, plan.Service.ValueString(), variables)
 
@@ -234,7 +310,7 @@ func (r *serviceEnvironmentResource) Update(ctx context.Context, req resource.Up
 	if response.StatusCode() != http.StatusOK {
 		resp.Diagnostics.AddError(
 			"Failed to update service variables",
-			fmt.Sprintf("Could not update service [%s] variables\nresponse: %s %s\nNote: If you're trying to use 'generated' and the response says 'invalid JSON', this is an issue with the render api, not this provider.",
+			fmt.Sprintf("Could not update service [%s] variables\nresponse: %s %s",
 				plan.Service.ValueString(),
 				response.Status(),
 				string(response.Body),
@@ -243,14 +319,9 @@ func (r *serviceEnvironmentResource) Update(ctx context.Context, req resource.Up
 		return
 	}
 
-	s := response.JSON200
-
-	tflog.Debug(ctx, "Update service env vars: "+response.Status(), map[string]interface{}{
-		"s": s,
-		"r": string(response.Body),
-	})
+	tflog.Debug(ctx, "Update service env vars: "+response.Status())
 
-	resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan.WithGeneratedValues(envVarsFromResponse(response)))...)
 }
 
 func (r *serviceEnvironmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -259,13 +330,43 @@ func (r *serviceEnvironmentResource) Delete(ctx context.Context, req resource.De
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 
+	ctx, cancel := withTimeout(ctx, state.Timeouts.Delete, &resp.Diagnostics)
+	defer cancel()
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	var variables []render.EnvVarsPATCH_Item
+	if state.RetainOnDestroy.ValueBool() {
+		tflog.Debug(ctx, "retaining service variables", map[string]interface{}{
+			"service": state.Service.ValueString(),
+		})
+		return
+	}
+
+	tflog.Debug(ctx, "deleting service variables", map[string]interface{}{
+		"service": state.Service.ValueString(),
+	})
+
+	envVars, status, err := r.getEnvVars(ctx, state.Service.ValueString())
+
+	// The service is already gone, so are its variables.
+	if status == http.StatusNotFound {
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError("failed to read service variables", err.Error())
+		return
+	}
 
-	tflog.Debug(ctx, "deleting service variables")
+	// Only the variables managed here are removed, the others are sent back unchanged.
+	variables, err := state.ToEnvVarsPATCHWithout(envVars)
+
+	if err != nil {
+		resp.Diagnostics.AddError("failed to convert to item", err.Error())
+		return
+	}
 
 	response, err := r.client.UpdateEnvVarsForServiceWithResponse(ctx, state.Service.ValueString(), variables)
 
@@ -274,19 +375,70 @@ func (r *serviceEnvironmentResource) Delete(ctx context.Context, req resource.De
 		return
 	}
 
-	if response.StatusCode() != http.StatusCreated {
-		resp.Diagnostics.AddError("failed to update service variables", string(response.Body))
+	if response.StatusCode() != http.StatusOK {
+		resp.Diagnostics.AddError("failed to update service variables", fmt.Sprintf("%s %s", response.Status(), string(response.Body)))
 		return
 	}
 
-	s := response.JSON200
+	tflog.Debug(ctx, "Update service env vars: "+response.Status())
+}
+
+// ImportState imports every variable of the service given as ID. Move the ones
+// that should not be read back to `sensitive_variables` afterwards.
+func (r *serviceEnvironmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("service"), req, resp)
+}
+
+func (r *serviceEnvironmentResource) getEnvVars(ctx context.Context, serviceId string) ([]render.EnvVar, int, error) {
+	limit := render.LimitParam(utils.PageLimit)
+	params := &render.GetEnvVarsForServiceParams{Limit: &limit}
+	status := 0
+
+	envVars, err := utils.Paginate(func(cursor *[]byte) (utils.Page[render.EnvVar, []byte], error) {
+		var page utils.Page[render.EnvVar, []byte]
 
-	tflog.Debug(ctx, "Update service env vars: "+response.Status(), map[string]interface{}{
-		"s": s,
-		"r": string(response.Body),
+		params.Cursor = cursor
+
+		response, err := r.client.GetEnvVarsForServiceWithResponse(ctx, serviceId, params)
+
+		if err != nil {
+			return page, err
+		}
+
+		status = response.StatusCode()
+
+		if status != http.StatusOK {
+			return page, fmt.Errorf("%s %s", response.Status(), string(response.Body))
+		}
+
+		for _, item := range *response.JSON200 {
+			if item.EnvVar != nil {
+				page.Items = append(page.Items, *item.EnvVar)
+			}
+
+			page.Cursor = item.Cursor
+		}
+
+		page.Size = len(*response.JSON200)
+
+		return page, nil
 	})
 
-	//result := plan.FromResponse(*s)
+	return envVars, status, err
+}
+
+func envVarsFromResponse(response *render.UpdateEnvVarsForServiceResponse) []render.EnvVar {
+	var envVars []render.EnvVar
+
+	if response.JSON200 == nil {
+		return envVars
+	}
+
+	for _, item := range *response.JSON200 {
+		if item.EnvVar != nil {
+			envVars = append(envVars, *item.EnvVar)
+		}
+	}
 
-	//resp.State.Set(ctx, state)
+	return envVars
 }
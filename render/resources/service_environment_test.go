@@ -0,0 +1,148 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jackall3n/render-go"
+	"github.com/jackall3n/terraform-provider-render/internal/fakerender"
+	"github.com/jackall3n/terraform-provider-render/render/api"
+	"github.com/jackall3n/terraform-provider-render/render/models"
+)
+
+// newFakeService starts a fake Render API with one web service holding envVars.
+func newFakeService(t *testing.T, envVars []render.EnvVar) (*fakerender.Server, string) {
+	t.Helper()
+
+	server := fakerender.New()
+	t.Cleanup(server.Close)
+
+	id, err := server.AddService(api.ServicePOST{ServicePOST: render.ServicePOST{Name: "api", OwnerId: "usr-1", Repo: "repo", Type: render.WebService}})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server.SetEnvVars(id, envVars)
+
+	return server, id
+}
+
+func deleteServiceEnvironment(t *testing.T, server *fakerender.Server, state models.ServiceEnvironment) resource.DeleteResponse {
+	t.Helper()
+
+	ctx := context.Background()
+
+	client, err := render.NewClientWithResponses(server.URL)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := &serviceEnvironmentResource{client: client}
+
+	schemaResponse := resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResponse)
+
+	current := tfsdk.State{Schema: schemaResponse.Schema}
+
+	if diags := current.Set(ctx, state); diags.HasError() {
+		t.Fatalf("failed to set state: %v", diags)
+	}
+
+	resp := resource.DeleteResponse{State: current}
+	r.Delete(ctx, resource.DeleteRequest{State: current}, &resp)
+
+	return resp
+}
+
+func managedEnvironment(serviceId string, retain bool) models.ServiceEnvironment {
+	return models.ServiceEnvironment{
+		Service: types.StringValue(serviceId),
+		Variables: map[string]models.ServiceEnvironmentVariable{
+			"MANAGED": {Value: types.StringValue("1"), Generated: types.BoolNull()},
+		},
+		SensitiveVariables: map[string]types.String{"PASSWORD": types.StringValue("secret")},
+		GeneratedValues:    types.MapNull(types.StringType),
+		RetainOnDestroy:    types.BoolValue(retain),
+		Timeouts:           models.NullTimeouts(),
+	}
+}
+
+func TestServiceEnvironmentDeleteRemovesManagedVariables(t *testing.T) {
+	server, id := newFakeService(t, []render.EnvVar{
+		{Key: "MANAGED", Value: "1"},
+		{Key: "PASSWORD", Value: "rotated"},
+		{Key: "UNMANAGED", Value: "2"},
+	})
+
+	resp := deleteServiceEnvironment(t, server, managedEnvironment(id, false))
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	envVars := server.EnvVars(id)
+
+	if len(envVars) != 1 || envVars[0] != (render.EnvVar{Key: "UNMANAGED", Value: "2"}) {
+		t.Errorf("expected only UNMANAGED to be left, got %v", envVars)
+	}
+}
+
+func TestServiceEnvironmentDeleteRetainOnDestroy(t *testing.T) {
+	server, id := newFakeService(t, []render.EnvVar{{Key: "MANAGED", Value: "1"}})
+
+	resp := deleteServiceEnvironment(t, server, managedEnvironment(id, true))
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	if requests := server.Requests(); len(requests) != 0 {
+		t.Errorf("expected no requests, got %v", requests)
+	}
+
+	if envVars := server.EnvVars(id); len(envVars) != 1 {
+		t.Errorf("expected MANAGED to be kept, got %v", envVars)
+	}
+}
+
+func TestServiceEnvironmentDeleteMissingService(t *testing.T) {
+	server, id := newFakeService(t, nil)
+	server.DeleteService(id)
+
+	resp := deleteServiceEnvironment(t, server, managedEnvironment(id, false))
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("expected deleting the variables of a deleted service to succeed, got %v", resp.Diagnostics)
+	}
+}
+
+func TestServiceEnvironmentDeleteTimeout(t *testing.T) {
+	server, id := newFakeService(t, []render.EnvVar{{Key: "MANAGED", Value: "1"}})
+
+	state := managedEnvironment(id, false)
+	state.Timeouts = timeouts.Value{Object: types.ObjectValueMust(
+		map[string]attr.Type{"create": types.StringType, "read": types.StringType, "update": types.StringType, "delete": types.StringType},
+		map[string]attr.Value{"create": types.StringNull(), "read": types.StringNull(), "update": types.StringNull(), "delete": types.StringValue("1ns")},
+	)}
+
+	resp := deleteServiceEnvironment(t, server, state)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error once the delete timeout passed")
+	}
+
+	if requests := server.Requests(); len(requests) != 0 {
+		t.Errorf("expected no requests, got %v", requests)
+	}
+
+	if envVars := server.EnvVars(id); len(envVars) != 1 {
+		t.Errorf("expected MANAGED to be kept, got %v", envVars)
+	}
+}
@@ -0,0 +1,273 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jackall3n/render-go"
+	"github.com/jackall3n/terraform-provider-render/render/api"
+	"github.com/jackall3n/terraform-provider-render/render/models"
+	"github.com/jackall3n/terraform-provider-render/render/types"
+	"github.com/jackall3n/terraform-provider-render/render/utils"
+	"net/http"
+)
+
+func ServiceHeadersResource() resource.Resource {
+	return &serviceHeadersResource{}
+}
+
+type serviceHeadersResource struct {
+	client  *render.ClientWithResponses
+	api     *api.Client
+	context *types.Context
+}
+
+var _ resource.ResourceWithImportState = (*serviceHeadersResource)(nil)
+
+func (r *serviceHeadersResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_service_headers"
+}
+
+func (r *serviceHeadersResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	ctx, ok := req.ProviderData.(*types.Context)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *types.Context, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.context = ctx
+	r.client = ctx.Client
+	r.api = ctx.API
+}
+
+// Schema returns the schema information for a service headers resource.
+func (r *serviceHeadersResource) Schema(_ context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `Manages the full set of custom response headers of a static site.`,
+		Attributes: map[string]schema.Attribute{
+			"service_id": schema.StringAttribute{Required: true, PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+
+			"headers": schema.SetNestedAttribute{
+				Description: "Header rules applied to the static site, in any order. Rules not listed here are removed.",
+				Required:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"path":  schema.StringAttribute{Required: true, Description: "Path pattern the header applies to, e.g. `/*` or `/static/*`."},
+						"name":  schema.StringAttribute{Required: true},
+						"value": schema.StringAttribute{Required: true},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *serviceHeadersResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan models.ServiceHeaders
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	service, err := r.client.GetServiceWithResponse(ctx, plan.ServiceID.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("failed to get service", err.Error())
+		return
+	}
+
+	if service.StatusCode() != http.StatusOK {
+		resp.Diagnostics.AddError("failed to get service", fmt.Sprintf("%s %s", service.Status(), string(service.Body)))
+		return
+	}
+
+	if service.JSON200.Type == nil || *service.JSON200.Type != render.StaticSite {
+		resp.Diagnostics.AddError(
+			"Invalid service type",
+			fmt.Sprintf("Custom headers can only be set on services of type 'static_site', service [%s] is not one", plan.ServiceID.ValueString()),
+		)
+		return
+	}
+
+	result, err := r.updateHeaders(ctx, plan)
+
+	if err != nil {
+		resp.Diagnostics.AddError("failed to create service headers", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, result)...)
+}
+
+func (r *serviceHeadersResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state models.ServiceHeaders
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "reading service headers", map[string]interface{}{
+		"service_id": state.ServiceID.ValueString(),
+	})
+
+	headers, status, err := r.getHeaders(ctx, state.ServiceID.ValueString())
+
+	if status == http.StatusNotFound {
+		tflog.Warn(ctx, "service not found, removing headers from state", map[string]interface{}{
+			"service_id": state.ServiceID.ValueString(),
+		})
+
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading service headers",
+			fmt.Sprintf("Could not read headers of service %s, unexpected error: %s",
+				state.ServiceID.ValueString(),
+				err,
+			),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state.FromResponse(headers))...)
+}
+
+func (r *serviceHeadersResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan models.ServiceHeaders
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.updateHeaders(ctx, plan)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating service headers",
+			fmt.Sprintf("Could not update headers of service %s, unexpected error: %s",
+				plan.ServiceID.ValueString(),
+				err,
+			),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, result)...)
+}
+
+func (r *serviceHeadersResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state models.ServiceHeaders
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "deleting service headers", map[string]interface{}{
+		"service_id": state.ServiceID.ValueString(),
+	})
+
+	response, err := r.api.UpdateHeadersWithResponse(ctx, state.ServiceID.ValueString(), []render.Header{})
+
+	if err != nil {
+		resp.Diagnostics.AddError("failed to delete service headers", err.Error())
+		return
+	}
+
+	// The service is already gone, so are its headers.
+	if response.StatusCode() == http.StatusNotFound {
+		return
+	}
+
+	if response.StatusCode() != http.StatusOK {
+		resp.Diagnostics.AddError("failed to delete service headers", fmt.Sprintf("%s %s", response.Status(), string(response.Body)))
+		return
+	}
+}
+
+func (r *serviceHeadersResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("service_id"), req, resp)
+}
+
+func (r *serviceHeadersResource) updateHeaders(ctx context.Context, plan models.ServiceHeaders) (models.ServiceHeaders, error) {
+	headers := plan.ToHeaders()
+
+	tflog.Debug(ctx, "setting service headers", utils.ToJson(map[string]interface{}{
+		"service_id": plan.ServiceID.ValueString(),
+		"headers":    headers,
+	}))
+
+	response, err := r.api.UpdateHeadersWithResponse(ctx, plan.ServiceID.ValueString(), headers)
+
+	if err != nil {
+		return plan, err
+	}
+
+	if response.StatusCode() != http.StatusOK {
+		return plan, fmt.Errorf("%s %s", response.Status(), string(response.Body))
+	}
+
+	return plan.FromResponse(*response.JSON200), nil
+}
+
+func (r *serviceHeadersResource) getHeaders(ctx context.Context, serviceId string) ([]render.Header, int, error) {
+	limit := render.LimitParam(utils.PageLimit)
+	params := &render.GetHeadersParams{Limit: &limit}
+	status := 0
+
+	headers, err := utils.Paginate(func(cursor *[]byte) (utils.Page[render.Header, []byte], error) {
+		var page utils.Page[render.Header, []byte]
+
+		params.Cursor = cursor
+
+		response, err := r.client.GetHeadersWithResponse(ctx, serviceId, params)
+
+		if err != nil {
+			return page, err
+		}
+
+		status = response.StatusCode()
+
+		if status != http.StatusOK {
+			return page, fmt.Errorf("%s %s", response.Status(), string(response.Body))
+		}
+
+		for _, item := range *response.JSON200 {
+			if item.Headers != nil {
+				page.Items = append(page.Items, *item.Headers)
+			}
+
+			page.Cursor = item.Cursor
+		}
+
+		page.Size = len(*response.JSON200)
+
+		return page, nil
+	})
+
+	return headers, status, err
+}
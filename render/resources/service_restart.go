@@ -0,0 +1,278 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jackall3n/render-go"
+	"github.com/jackall3n/terraform-provider-render/render/api"
+	"github.com/jackall3n/terraform-provider-render/render/models"
+	"github.com/jackall3n/terraform-provider-render/render/types"
+	"net/http"
+	"time"
+)
+
+func ServiceRestartResource() resource.Resource {
+	return &serviceRestartResource{}
+}
+
+type serviceRestartResource struct {
+	client  *render.ClientWithResponses
+	api     *api.Client
+	context *types.Context
+}
+
+func (r *serviceRestartResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_service_restart"
+}
+
+func (r *serviceRestartResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	ctx, ok := req.ProviderData.(*types.Context)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *types.Context, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.context = ctx
+	r.client = ctx.Client
+	r.api = ctx.API
+}
+
+// Schema returns the schema information for a service restart resource.
+func (r *serviceRestartResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	unknown := []planmodifier.String{stringplanmodifier.UseStateForUnknown()}
+
+	resp.Schema = schema.Schema{
+		Description: "Restarts a service when it is created and whenever `triggers` change, e.g. after rotating a secret, " +
+			"and waits until the service is healthy again. Destroying it does nothing.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{Computed: true, PlanModifiers: unknown},
+			"service_id": schema.StringAttribute{
+				Description:   "The ID of the service to restart.",
+				Required:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"triggers": schema.MapAttribute{
+				Description:   "Arbitrary values that restart the service when they change, e.g. the `id` of a `render_service_environment`.",
+				Optional:      true,
+				ElementType:   basetypes.StringType{},
+				PlanModifiers: []planmodifier.Map{mapplanmodifier.RequiresReplace()},
+			},
+			"clear_cache": schema.BoolAttribute{
+				Description: "Deploy the service again with a cleared build cache instead of restarting its instances. " +
+					"Changing it doesn't restart the service, only the next change of `triggers` uses it.",
+				Optional: true,
+			},
+			"deploy_id": schema.StringAttribute{
+				Description:   "The ID of the deploy that cleared the cache, if `clear_cache` is set.",
+				Computed:      true,
+				PlanModifiers: unknown,
+			},
+
+			"timeouts": timeouts.AttributesAll(ctx),
+		},
+	}
+}
+
+func (r *serviceRestartResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan models.ServiceRestart
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	ctx, cancel := withTimeout(ctx, plan.Timeouts.Create, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	serviceId := plan.ServiceID.ValueString()
+
+	plan.ID = plan.ServiceID
+	plan.DeployID = basetypes.NewStringNull()
+
+	if plan.ClearCache.ValueBool() {
+		clear := render.Clear
+
+		tflog.Debug(ctx, "deploying service with a cleared cache", map[string]interface{}{
+			"service_id": serviceId,
+		})
+
+		response, err := r.client.CreateDeployWithResponse(ctx, serviceId, render.CreateDeployJSONRequestBody{ClearCache: &clear})
+
+		if err != nil {
+			resp.Diagnostics.AddError("failed to deploy service", err.Error())
+			return
+		}
+
+		if response.StatusCode() != http.StatusCreated {
+			resp.Diagnostics.AddError("failed to deploy service", fmt.Sprintf("%s %s", response.Status(), string(response.Body)))
+			return
+		}
+
+		deploy, err := waitForDeploy(ctx, r.client, serviceId, response.JSON201.Id)
+
+		if err != nil {
+			resp.Diagnostics.AddError("failed to deploy service", err.Error())
+			return
+		}
+
+		if *deploy.Status != render.Live {
+			resp.Diagnostics.AddError("failed to deploy service", fmt.Sprintf("deploy %s ended with status %s, check its logs in the Render dashboard", deploy.Id, *deploy.Status))
+			return
+		}
+
+		plan.DeployID = basetypes.NewStringValue(deploy.Id)
+
+		resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+		return
+	}
+
+	if err := r.restart(ctx, serviceId); err != nil {
+		resp.Diagnostics.AddError("failed to restart service", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read only checks that the service still exists, a restart leaves nothing else to read.
+func (r *serviceRestartResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state models.ServiceRestart
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	ctx, cancel := withTimeout(ctx, state.Timeouts.Read, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	response, err := r.api.GetServiceWithResponse(ctx, state.ServiceID.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading service",
+			fmt.Sprintf("Could not read service %s, unexpected error: %s", state.ServiceID.ValueString(), err),
+		)
+		return
+	}
+
+	if response.StatusCode() == http.StatusNotFound {
+		tflog.Warn(ctx, "service not found, removing its restart from state", map[string]interface{}{
+			"service_id": state.ServiceID.ValueString(),
+		})
+
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if response.StatusCode() != http.StatusOK {
+		resp.Diagnostics.AddError("failed to get service", fmt.Sprintf("%s %s", response.Status(), string(response.Body)))
+	}
+}
+
+// Update only stores `clear_cache` and `timeouts`, every other change replaces the resource.
+func (r *serviceRestartResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state models.ServiceRestart
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = state.ID
+	plan.DeployID = state.DeployID
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete only removes the resource from state, a restart can't be undone.
+func (r *serviceRestartResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+}
+
+// restart restarts the instances of a service and waits until they are healthy. The last health
+// event is noted before the restart, so that only the events of this restart are waited for.
+func (r *serviceRestartResource) restart(ctx context.Context, serviceId string) error {
+	previous, err := r.lastHealthEvent(ctx, serviceId)
+
+	if err != nil {
+		return err
+	}
+
+	tflog.Debug(ctx, "restarting service", map[string]interface{}{
+		"service_id": serviceId,
+	})
+
+	response, err := r.api.RestartServiceWithResponse(ctx, serviceId)
+
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode() >= http.StatusBadRequest {
+		return fmt.Errorf("%s %s", response.Status(), string(response.Body))
+	}
+
+	for {
+		event, err := r.lastHealthEvent(ctx, serviceId)
+
+		if err != nil {
+			return err
+		}
+
+		if event != nil && (previous == nil || event.Id != previous.Id) {
+			if event.Type == api.ServerFailed {
+				return fmt.Errorf("the service failed to become healthy after the restart, check its logs in the Render dashboard")
+			}
+
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for the service to become healthy, raise `timeouts`: %s", ctx.Err())
+		case <-time.After(deployInterval):
+		}
+	}
+}
+
+// lastHealthEvent returns the newest event of a service becoming healthy or failing, if there is one.
+func (r *serviceRestartResource) lastHealthEvent(ctx context.Context, serviceId string) (*api.Event, error) {
+	response, err := r.api.ListServiceEventsWithResponse(ctx, serviceId, []api.EventType{api.ServerAvailable, api.ServerFailed}, nil, 1)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("%s %s", response.Status(), string(response.Body))
+	}
+
+	for _, item := range *response.JSON200 {
+		if item.Event != nil {
+			return item.Event, nil
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,205 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jackall3n/render-go"
+	"github.com/jackall3n/terraform-provider-render/render/api"
+	"github.com/jackall3n/terraform-provider-render/render/models"
+	"github.com/jackall3n/terraform-provider-render/render/types"
+	"net/http"
+)
+
+func ServiceRollbackResource() resource.Resource {
+	return &serviceRollbackResource{}
+}
+
+type serviceRollbackResource struct {
+	client  *render.ClientWithResponses
+	api     *api.Client
+	context *types.Context
+}
+
+func (r *serviceRollbackResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_service_rollback"
+}
+
+func (r *serviceRollbackResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	ctx, ok := req.ProviderData.(*types.Context)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *types.Context, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.context = ctx
+	r.client = ctx.Client
+	r.api = ctx.API
+}
+
+// Schema returns the schema information for a service rollback resource.
+func (r *serviceRollbackResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	unknown := []planmodifier.String{stringplanmodifier.UseStateForUnknown()}
+	replace := []planmodifier.String{stringplanmodifier.RequiresReplace()}
+
+	resp.Schema = schema.Schema{
+		Description: "Rolls a service back to an earlier deploy and waits until the rollback deploy is live. " +
+			"Changing `deploy_id` rolls back again. Destroying it does nothing, the service keeps running the rollback deploy " +
+			"until it is deployed again.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description:   "The ID of the deploy started by the rollback.",
+				Computed:      true,
+				PlanModifiers: unknown,
+			},
+			"service_id": schema.StringAttribute{
+				Description:   "The ID of the service to roll back.",
+				Required:      true,
+				PlanModifiers: replace,
+			},
+			"deploy_id": schema.StringAttribute{
+				Description:   "The ID of the earlier deploy to roll back to.",
+				Required:      true,
+				PlanModifiers: replace,
+			},
+			"status": schema.StringAttribute{
+				Description: "The status of the rollback deploy, `live` once the rollback has finished, " +
+					"`deactivated` once the service was deployed again, and null once Render no longer keeps the deploy.",
+				Computed: true,
+			},
+
+			"timeouts": timeouts.AttributesAll(ctx),
+		},
+	}
+}
+
+func (r *serviceRollbackResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan models.ServiceRollback
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	ctx, cancel := withTimeout(ctx, plan.Timeouts.Create, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	serviceId := plan.ServiceID.ValueString()
+
+	tflog.Debug(ctx, "rolling back service", map[string]interface{}{
+		"service_id": serviceId,
+		"deploy_id":  plan.DeployID.ValueString(),
+	})
+
+	response, err := r.api.RollbackDeployWithResponse(ctx, serviceId, plan.DeployID.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("failed to roll back service", err.Error())
+		return
+	}
+
+	if response.StatusCode() != http.StatusCreated {
+		resp.Diagnostics.AddError("failed to roll back service", fmt.Sprintf("%s %s", response.Status(), string(response.Body)))
+		return
+	}
+
+	deploy, err := waitForDeploy(ctx, r.client, serviceId, response.JSON201.Id)
+
+	if err != nil {
+		resp.Diagnostics.AddError("failed to roll back service", err.Error())
+		return
+	}
+
+	if *deploy.Status != render.Live {
+		resp.Diagnostics.AddError("failed to roll back service", fmt.Sprintf("deploy %s ended with status %s, check its logs in the Render dashboard", deploy.Id, *deploy.Status))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan.FromResponse(deploy))...)
+}
+
+func (r *serviceRollbackResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state models.ServiceRollback
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	ctx, cancel := withTimeout(ctx, state.Timeouts.Read, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	response, err := r.client.GetDeployWithResponse(ctx, state.ServiceID.ValueString(), state.ID.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading deploy",
+			fmt.Sprintf("Could not read deploy %s, unexpected error: %s", state.ID.ValueString(), err),
+		)
+		return
+	}
+
+	// Removing the rollback from state would roll the service back again on the next apply,
+	// so a deploy Render no longer keeps only loses its status.
+	if response.StatusCode() == http.StatusNotFound {
+		tflog.Warn(ctx, "rollback deploy not found, keeping it in state", map[string]interface{}{
+			"id": state.ID.ValueString(),
+		})
+
+		resp.Diagnostics.AddWarning(
+			"Rollback deploy not found",
+			fmt.Sprintf("Deploy %s of the rollback no longer exists, its status is unknown. "+
+				"Change `deploy_id` to roll the service back again.", state.ID.ValueString()),
+		)
+
+		state.Status = basetypes.NewStringNull()
+
+		resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+		return
+	}
+
+	if response.StatusCode() != http.StatusOK {
+		resp.Diagnostics.AddError("failed to get deploy", fmt.Sprintf("%s %s", response.Status(), string(response.Body)))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state.FromResponse(*response.JSON200))...)
+}
+
+// Update only stores `timeouts`, every other change replaces the resource.
+func (r *serviceRollbackResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state models.ServiceRollback
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = state.ID
+	plan.Status = state.Status
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete only removes the resource from state, a rollback can't be undone.
+func (r *serviceRollbackResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+}
@@ -0,0 +1,19 @@
+package resources
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"time"
+)
+
+// defaultTimeout bounds every operation that has no configured `timeouts`.
+const defaultTimeout = 10 * time.Minute
+
+// withTimeout returns a context with the deadline of an operation, e.g. plan.Timeouts.Create,
+// so that every client call and polling loop stops once it is reached.
+func withTimeout(ctx context.Context, timeout func(context.Context, time.Duration) (time.Duration, diag.Diagnostics), diags *diag.Diagnostics) (context.Context, context.CancelFunc) {
+	duration, d := timeout(ctx, defaultTimeout)
+	diags.Append(d...)
+
+	return context.WithTimeout(ctx, duration)
+}
@@ -0,0 +1,106 @@
+package render
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/jackall3n/terraform-provider-render/internal/fakerender"
+)
+
+func testAccServiceCustomDomainConfig(domainName string) string {
+	return testAccStaticSiteConfig("client", "out") + fmt.Sprintf(`
+resource "render_service_custom_domain" "client" {
+  service_id            = render_service.client.id
+  domain_name           = %q
+  wait_for_verification = true
+
+  timeouts = {
+    create = "5m"
+  }
+}
+`, domainName)
+}
+
+// testAccDeleteCustomDomain removes a custom domain behind Terraform's back.
+func testAccDeleteCustomDomain(t *testing.T, server *fakerender.Server, serviceId *string, domainName string) func() {
+	return func() {
+		request, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/services/%s/custom-domains/%s", server.URL, *serviceId, domainName), nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		response, err := http.DefaultClient.Do(request)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		response.Body.Close()
+	}
+}
+
+func TestAccServiceCustomDomainResource(t *testing.T) {
+	server := newTestAccServer(t)
+
+	var serviceId, id string
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckServicesDestroyed(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(server, testAccServiceCustomDomainConfig("www.example.com")),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("render_service_custom_domain.client", "id"),
+					resource.TestCheckResourceAttr("render_service_custom_domain.client", "domain_type", "subdomain"),
+					resource.TestCheckResourceAttr("render_service_custom_domain.client", "verification_status", "verified"),
+					resource.TestCheckResourceAttr("render_service_custom_domain.client", "dns_records.0.type", "CNAME"),
+					testAccCaptureID("render_service.client", "id", &serviceId),
+					testAccCaptureID("render_service_custom_domain.client", "id", &id),
+				),
+			},
+			{
+				Config: testAccConfig(server, testAccServiceCustomDomainConfig("example.com")),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("render_service_custom_domain.client", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckChangedID("render_service_custom_domain.client", "id", &id),
+					resource.TestCheckResourceAttr("render_service_custom_domain.client", "domain_type", "apex"),
+					resource.TestCheckResourceAttr("render_service_custom_domain.client", "dns_records.0.type", "A"),
+					testAccCaptureID("render_service_custom_domain.client", "id", &id),
+				),
+			},
+			{
+				ResourceName:      "render_service_custom_domain.client",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return serviceId + ":example.com", nil
+				},
+				ImportStateVerifyIgnore: []string{"wait_for_verification", "timeouts"},
+			},
+			{
+				PreConfig: testAccDeleteCustomDomain(t, server, &serviceId, "example.com"),
+				Config:    testAccConfig(server, testAccServiceCustomDomainConfig("example.com")),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckChangedID("render_service_custom_domain.client", "id", &id),
+					func(_ *terraform.State) error {
+						if len(server.CustomDomains(serviceId)) != 1 {
+							return fmt.Errorf("expected the custom domain to be recreated")
+						}
+
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,171 @@
+package render
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/jackall3n/render-go"
+	"github.com/jackall3n/terraform-provider-render/internal/fakerender"
+	"github.com/jackall3n/terraform-provider-render/render/api"
+)
+
+func testAccServiceEnvironmentConfig(variables string) string {
+	return testAccStaticSiteConfig("client", "out") + fmt.Sprintf(`
+resource "render_service_environment" "client" {
+  service = render_service.client.id
+
+  variables = {
+%s
+  }
+}
+`, variables)
+}
+
+func TestAccServiceEnvironmentResource(t *testing.T) {
+	server := newTestAccServer(t)
+
+	var serviceId, secret string
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckServicesDestroyed(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(server, testAccServiceEnvironmentConfig(`
+    API_URL = { value = "https://api.example.com" }
+    SECRET  = { generated = true }
+`)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("render_service_environment.client", "variables.API_URL.value", "https://api.example.com"),
+					resource.TestCheckResourceAttrSet("render_service_environment.client", "generated_values.SECRET"),
+					testAccCaptureID("render_service.client", "id", &serviceId),
+					testAccCaptureID("render_service_environment.client", "generated_values.SECRET", &secret),
+				),
+			},
+			{
+				Config: testAccConfig(server, testAccServiceEnvironmentConfig(`
+    API_URL = { value = "https://api.example.org" }
+    SECRET  = { generated = true }
+`)),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("render_service_environment.client", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("render_service_environment.client", "variables.API_URL.value", "https://api.example.org"),
+					// Updating another variable must not generate the secret again.
+					resource.TestCheckResourceAttrPtr("render_service_environment.client", "generated_values.SECRET", &secret),
+				),
+			},
+			{
+				ResourceName:                         "render_service_environment.client",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateIdFunc:                    testAccAttributeImportStateId("render_service.client", "id"),
+				ImportStateVerifyIdentifierAttribute: "service",
+				// Imported variables cannot tell whether their value was generated.
+				ImportStateVerifyIgnore: []string{"generated_values", "variables.SECRET"},
+			},
+			{
+				PreConfig: func() {
+					server.SetEnvVars(serviceId, nil)
+				},
+				Config: testAccConfig(server, testAccServiceEnvironmentConfig(`
+    API_URL = { value = "https://api.example.org" }
+    SECRET  = { generated = true }
+`)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("render_service_environment.client", "variables.API_URL.value", "https://api.example.org"),
+					resource.TestCheckResourceAttrSet("render_service_environment.client", "generated_values.SECRET"),
+					func(_ *terraform.State) error {
+						if len(server.EnvVars(serviceId)) != 2 {
+							return fmt.Errorf("expected the variables to be restored, got %v", server.EnvVars(serviceId))
+						}
+
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckEnvVar checks the value the fake server stores for a variable of a service.
+func testAccCheckEnvVar(server *fakerender.Server, serviceId string, key string, value string) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		for _, envVar := range server.EnvVars(serviceId) {
+			if envVar.Key == key {
+				if envVar.Value != value {
+					return fmt.Errorf("expected %s to be %q, got %q", key, value, envVar.Value)
+				}
+
+				return nil
+			}
+		}
+
+		return fmt.Errorf("variable %s not found", key)
+	}
+}
+
+func TestAccServiceEnvironmentResourceImportKeepsGeneratedValues(t *testing.T) {
+	server := newTestAccServer(t)
+
+	serviceId, err := server.AddService(api.ServicePOST{ServicePOST: render.ServicePOST{Name: "api", OwnerId: testAccOwnerID, Repo: "repo", Type: render.WebService}})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server.SetEnvVars(serviceId, []render.EnvVar{
+		{Key: "API_URL", Value: "https://api.example.com"},
+		{Key: "SECRET", Value: "generated-before-import"},
+	})
+
+	config := testAccConfig(server, fmt.Sprintf(`
+import {
+  to = render_service_environment.api
+  id = %[1]q
+}
+
+resource "render_service_environment" "api" {
+  service = %[1]q
+
+  variables = {
+    API_URL = { value = "https://api.example.com" }
+    SECRET  = { generated = true }
+  }
+}
+`, serviceId))
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Imported variables don't know they were generated, applying must not generate them again
+			{
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("render_service_environment.api", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("render_service_environment.api", "generated_values.SECRET", "generated-before-import"),
+					testAccCheckEnvVar(server, serviceId, "SECRET", "generated-before-import"),
+				),
+			},
+			{
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: testAccCheckEnvVar(server, serviceId, "SECRET", "generated-before-import"),
+			},
+		},
+	})
+}
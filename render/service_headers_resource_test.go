@@ -0,0 +1,131 @@
+package render
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/jackall3n/render-go"
+	"github.com/jackall3n/terraform-provider-render/internal/fakerender"
+)
+
+func testAccServiceHeadersConfig(cacheControl string) string {
+	return testAccStaticSiteConfig("client", "out") + fmt.Sprintf(`
+resource "render_service_headers" "client" {
+  service_id = render_service.client.id
+
+  headers = [
+    {
+      path  = "/*"
+      name  = "X-Frame-Options"
+      value = "DENY"
+    },
+    {
+      path  = "/static/*"
+      name  = "Cache-Control"
+      value = %q
+    },
+  ]
+}
+`, cacheControl)
+}
+
+// testAccCheckHeaders checks the headers the fake server stores for a service.
+func testAccCheckHeaders(server *fakerender.Server, serviceId *string, expected ...render.Header) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		headers := server.Headers(*serviceId)
+
+		if len(headers) != len(expected) {
+			return fmt.Errorf("expected %d headers, got %v", len(expected), headers)
+		}
+
+		for _, header := range expected {
+			found := false
+
+			for _, actual := range headers {
+				found = found || actual == header
+			}
+
+			if !found {
+				return fmt.Errorf("expected header %v, got %v", header, headers)
+			}
+		}
+
+		return nil
+	}
+}
+
+func TestAccServiceHeadersResource(t *testing.T) {
+	server := newTestAccServer(t)
+
+	var serviceId string
+
+	frameOptions := render.Header{Path: "/*", Name: "X-Frame-Options", Value: "DENY"}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckServicesDestroyed(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(server, testAccServiceHeadersConfig("max-age=60")),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCaptureID("render_service.client", "id", &serviceId),
+					resource.TestCheckResourceAttrPair("render_service_headers.client", "service_id", "render_service.client", "id"),
+					resource.TestCheckResourceAttr("render_service_headers.client", "headers.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("render_service_headers.client", "headers.*", map[string]string{
+						"path":  "/static/*",
+						"name":  "Cache-Control",
+						"value": "max-age=60",
+					}),
+					testAccCheckHeaders(server, &serviceId, frameOptions, render.Header{Path: "/static/*", Name: "Cache-Control", Value: "max-age=60"}),
+				),
+			},
+			{
+				Config: testAccConfig(server, testAccServiceHeadersConfig("max-age=3600")),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("render_service_headers.client", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs("render_service_headers.client", "headers.*", map[string]string{
+						"path":  "/static/*",
+						"name":  "Cache-Control",
+						"value": "max-age=3600",
+					}),
+					testAccCheckHeaders(server, &serviceId, frameOptions, render.Header{Path: "/static/*", Name: "Cache-Control", Value: "max-age=3600"}),
+				),
+			},
+			{
+				// The API may return the headers in another order than they were configured in.
+				PreConfig: func() {
+					server.SetHeaders(serviceId, []render.Header{
+						{Path: "/static/*", Name: "Cache-Control", Value: "max-age=3600"},
+						frameOptions,
+					})
+				},
+				Config: testAccConfig(server, testAccServiceHeadersConfig("max-age=3600")),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			{
+				ResourceName:                         "render_service_headers.client",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "service_id",
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return serviceId, nil
+				},
+			},
+			{
+				Config: testAccConfig(server, testAccStaticSiteConfig("client", "out")),
+				Check:  testAccCheckHeaders(server, &serviceId),
+			},
+		},
+	})
+}
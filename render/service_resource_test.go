@@ -0,0 +1,638 @@
+package render
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/jackall3n/render-go"
+	"github.com/jackall3n/terraform-provider-render/internal/fakerender"
+)
+
+func testAccWebServiceConfig(name string, repo string, plan string) string {
+	return fmt.Sprintf(`
+resource "render_service" "api" {
+  name = %q
+  repo = %q
+  type = "web_service"
+
+  web_service_details = {
+    env  = "node"
+    plan = %q
+
+    native = {
+      build_command = "npm install"
+      start_command = "node server.js"
+    }
+  }
+}
+`, name, repo, plan)
+}
+
+func TestAccServiceResource_webService(t *testing.T) {
+	server := newTestAccServer(t)
+
+	var id string
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckServicesDestroyed(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(server, testAccWebServiceConfig("api", "https://github.com/render-examples/express-hello-world", "starter")),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("render_service.api", "id"),
+					resource.TestCheckResourceAttr("render_service.api", "owner", testAccOwnerID),
+					resource.TestCheckResourceAttr("render_service.api", "web_service_details.region", "oregon"),
+					resource.TestCheckResourceAttr("render_service.api", "web_service_details.native.start_command", "node server.js"),
+					resource.TestCheckResourceAttrSet("render_service.api", "web_service_details.url"),
+					testAccCaptureID("render_service.api", "id", &id),
+				),
+			},
+			{
+				Config: testAccConfig(server, testAccWebServiceConfig("api-renamed", "https://github.com/render-examples/express-hello-world", "standard")),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("render_service.api", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPtr("render_service.api", "id", &id),
+					resource.TestCheckResourceAttr("render_service.api", "name", "api-renamed"),
+					resource.TestCheckResourceAttr("render_service.api", "web_service_details.plan", "standard"),
+				),
+			},
+			{
+				Config: testAccConfig(server, testAccWebServiceConfig("api-renamed", "https://github.com/render-examples/fastify-hello-world", "standard")),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("render_service.api", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckChangedID("render_service.api", "id", &id),
+					resource.TestCheckResourceAttr("render_service.api", "repo", "https://github.com/render-examples/fastify-hello-world"),
+					testAccCaptureID("render_service.api", "id", &id),
+				),
+			},
+			{
+				ResourceName:      "render_service.api",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				PreConfig: func() {
+					server.DeleteService(id)
+				},
+				Config: testAccConfig(server, testAccWebServiceConfig("api-renamed", "https://github.com/render-examples/fastify-hello-world", "standard")),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckChangedID("render_service.api", "id", &id),
+				),
+			},
+		},
+	})
+}
+
+func testAccDockerWebServiceConfig(settings string) string {
+	return fmt.Sprintf(`
+resource "render_service" "docker" {
+  name = "docker"
+  repo = "https://github.com/render-examples/docker"
+  type = "web_service"
+
+  web_service_details = {
+    env = "docker"
+    %s
+  }
+}
+`, settings)
+}
+
+func TestAccServiceResource_dockerWebService(t *testing.T) {
+	server := newTestAccServer(t)
+
+	var id string
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckServicesDestroyed(server),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccConfig(server, testAccDockerWebServiceConfig(`max_shutdown_delay_seconds = 600`)),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`max_shutdown_delay_seconds value must be\s+between 1 and 300, got: 600`),
+			},
+			{
+				Config: testAccConfig(server, testAccDockerWebServiceConfig(`
+    docker_command             = "./bin/server --port 10000"
+    max_shutdown_delay_seconds = 120
+`)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("render_service.docker", "web_service_details.docker_command", "./bin/server --port 10000"),
+					resource.TestCheckResourceAttr("render_service.docker", "web_service_details.max_shutdown_delay_seconds", "120"),
+					testAccCaptureID("render_service.docker", "id", &id),
+				),
+			},
+			{
+				ResourceName:      "render_service.docker",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccConfig(server, testAccDockerWebServiceConfig("")),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("render_service.docker", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPtr("render_service.docker", "id", &id),
+					resource.TestCheckNoResourceAttr("render_service.docker", "web_service_details.docker_command"),
+					// Removing the setting keeps the last value, as Render has no way to reset it
+					resource.TestCheckResourceAttr("render_service.docker", "web_service_details.max_shutdown_delay_seconds", "120"),
+				),
+			},
+		},
+	})
+}
+
+func testAccPrivateServiceConfig(name string, plan string) string {
+	return fmt.Sprintf(`
+resource "render_service" "db" {
+  name = %q
+  repo = "https://github.com/render-examples/mongodb"
+  type = "private_service"
+
+  private_service_details = {
+    env  = "docker"
+    plan = %q
+
+    disk = {
+      name       = "db"
+      mount_path = "/data/db"
+      size_gb    = 10
+    }
+  }
+}
+`, name, plan)
+}
+
+func TestAccServiceResource_privateService(t *testing.T) {
+	server := newTestAccServer(t)
+
+	var id string
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckServicesDestroyed(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(server, testAccPrivateServiceConfig("db", "starter")),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("render_service.db", "private_service_details.disk.name", "db"),
+					resource.TestCheckResourceAttr("render_service.db", "private_service_details.disk.mount_path", "/data/db"),
+					resource.TestCheckResourceAttrSet("render_service.db", "private_service_details.url"),
+					testAccCaptureID("render_service.db", "id", &id),
+				),
+			},
+			{
+				Config: testAccConfig(server, testAccPrivateServiceConfig("db", "standard")),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("render_service.db", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPtr("render_service.db", "id", &id),
+					resource.TestCheckResourceAttr("render_service.db", "private_service_details.plan", "standard"),
+				),
+			},
+			{
+				ResourceName:      "render_service.db",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The API does not return the mount path and size of disks.
+				ImportStateVerifyIgnore: []string{
+					"private_service_details.disk.mount_path",
+					"private_service_details.disk.size_gb",
+				},
+			},
+			{
+				PreConfig: func() {
+					server.DeleteService(id)
+				},
+				Config: testAccConfig(server, testAccPrivateServiceConfig("db", "standard")),
+				Check:  testAccCheckChangedID("render_service.db", "id", &id),
+			},
+		},
+	})
+}
+
+func testAccBackgroundWorkerConfig(preDeployCommand string) string {
+	return fmt.Sprintf(`
+resource "render_service" "worker" {
+  name = "worker"
+  repo = "https://github.com/render-examples/celery"
+  type = "background_worker"
+
+  background_worker_details = {
+    env                = "python"
+    pre_deploy_command = %s
+
+    native = {
+      build_command = "pip install -r requirements.txt"
+      start_command = "celery -A tasks worker"
+    }
+  }
+}
+`, preDeployCommand)
+}
+
+func TestAccServiceResource_backgroundWorker(t *testing.T) {
+	server := newTestAccServer(t)
+
+	var id string
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckServicesDestroyed(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(server, testAccBackgroundWorkerConfig(`"python manage.py migrate"`)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("render_service.worker", "background_worker_details.region", "oregon"),
+					resource.TestCheckResourceAttr("render_service.worker", "background_worker_details.pre_deploy_command", "python manage.py migrate"),
+					resource.TestCheckResourceAttr("render_service.worker", "background_worker_details.native.start_command", "celery -A tasks worker"),
+					testAccCaptureID("render_service.worker", "id", &id),
+				),
+			},
+			{
+				ResourceName:      "render_service.worker",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccConfig(server, testAccBackgroundWorkerConfig("null")),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("render_service.worker", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPtr("render_service.worker", "id", &id),
+					resource.TestCheckNoResourceAttr("render_service.worker", "background_worker_details.pre_deploy_command"),
+				),
+			},
+		},
+	})
+}
+
+func testAccStaticSiteConfig(name string, publishPath string) string {
+	return fmt.Sprintf(`
+resource "render_service" "client" {
+  name = %q
+  repo = "https://github.com/render-examples/nextjs-hello-world"
+  type = "static_site"
+
+  static_site_details = {
+    build_command = "yarn; yarn build; yarn next export"
+    publish_path  = %q
+  }
+}
+`, name, publishPath)
+}
+
+func TestAccServiceResource_staticSite(t *testing.T) {
+	server := newTestAccServer(t)
+
+	var id string
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckServicesDestroyed(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(server, testAccStaticSiteConfig("client", "out")),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("render_service.client", "static_site_details.publish_path", "out"),
+					resource.TestCheckResourceAttrSet("render_service.client", "static_site_details.url"),
+					testAccCaptureID("render_service.client", "id", &id),
+				),
+			},
+			{
+				Config: testAccConfig(server, testAccStaticSiteConfig("client", "dist")),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("render_service.client", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPtr("render_service.client", "id", &id),
+					resource.TestCheckResourceAttr("render_service.client", "static_site_details.publish_path", "dist"),
+				),
+			},
+			{
+				ResourceName:      "render_service.client",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				PreConfig: func() {
+					server.DeleteService(id)
+				},
+				Config: testAccConfig(server, testAccStaticSiteConfig("client", "dist")),
+				Check:  testAccCheckChangedID("render_service.client", "id", &id),
+			},
+		},
+	})
+}
+
+func testAccPreviewsConfig(details string, previews string) string {
+	return fmt.Sprintf(`
+resource "render_service" "client" {
+  name = "client"
+  repo = "https://github.com/render-examples/nextjs-hello-world"
+  type = "static_site"
+
+  static_site_details = {
+    build_command = "yarn; yarn build; yarn next export"
+    publish_path  = "out"
+    %s
+  }
+
+  previews {
+    %s
+  }
+}
+`, details, previews)
+}
+
+func TestAccServiceResource_previews(t *testing.T) {
+	server := newTestAccServer(t)
+
+	var id string
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckServicesDestroyed(server),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccConfig(server, testAccPreviewsConfig("pull_request_previews_enabled = true", `generation = "automatic"`)),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Attribute "previews" cannot be specified when\s+"static_site_details.pull_request_previews_enabled" is specified`),
+			},
+			{
+				Config:      testAccConfig(server, testAccPreviewsConfig("", `expire_after_days = 7`)),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Attribute "previews.generation" must be specified`),
+			},
+			{
+				Config:      testAccConfig(server, testAccPreviewsConfig("", `generation = "always"`)),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`previews.generation value must be one of`),
+			},
+			{
+				Config: testAccConfig(server, testAccPreviewsConfig("", `
+    generation        = "automatic"
+    expire_after_days = 7
+    plan              = "starter"
+`)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("render_service.client", "previews.generation", "automatic"),
+					resource.TestCheckResourceAttr("render_service.client", "previews.expire_after_days", "7"),
+					resource.TestCheckResourceAttr("render_service.client", "previews.plan", "starter"),
+					testAccCaptureID("render_service.client", "id", &id),
+				),
+			},
+			{
+				Config: testAccConfig(server, testAccPreviewsConfig("", `generation = "manual"`)),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("render_service.client", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPtr("render_service.client", "id", &id),
+					resource.TestCheckResourceAttr("render_service.client", "previews.generation", "manual"),
+					resource.TestCheckNoResourceAttr("render_service.client", "previews.expire_after_days"),
+					resource.TestCheckNoResourceAttr("render_service.client", "previews.plan"),
+				),
+			},
+			{
+				ResourceName:      "render_service.client",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// Previews are only read back once they are managed
+				ImportStateVerifyIgnore: []string{"previews.%", "previews.generation"},
+			},
+		},
+	})
+}
+
+func TestAccServiceResource_ownerName(t *testing.T) {
+	server := newTestAccServer(t)
+
+	name := "Platform"
+	teamType := render.OwnerTypeTeam
+	server.AddOwner(render.Owner{Id: "tea-00000001", Name: &name, Type: &teamType})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckServicesDestroyed(server),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "render" {
+  api_key    = "rnd_test"
+  api_url    = %q
+  email      = %q
+  owner_name = "Platform"
+}
+`, server.URL, testAccOwnerEmail) + testAccStaticSiteConfig("client", "out"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						expectPlannedValue{"render_service.client", tfjsonpath.New("owner"), "tea-00000001"},
+					},
+				},
+				Check: resource.TestCheckResourceAttr("render_service.client", "owner", "tea-00000001"),
+			},
+		},
+	})
+}
+
+func testAccMonorepoServiceConfig(rootDirectory string, buildFilter string) string {
+	return fmt.Sprintf(`
+resource "render_service" "api" {
+  name           = "api"
+  repo           = "https://github.com/render-examples/monorepo"
+  type           = "web_service"
+  root_directory = %q
+
+  %s
+
+  web_service_details = {
+    env = "node"
+
+    native = {
+      build_command = "npm install"
+      start_command = "node server.js"
+    }
+  }
+}
+`, rootDirectory, buildFilter)
+}
+
+func TestAccServiceResource_monorepo(t *testing.T) {
+	server := newTestAccServer(t)
+
+	var id string
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckServicesDestroyed(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(server, testAccMonorepoServiceConfig("services/api", `
+  build_filter {
+    paths = ["services/api/[a-z*"]
+  }
+`)),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`"services/api/\[a-z\*" is not a valid glob pattern`),
+			},
+			{
+				Config: testAccConfig(server, testAccMonorepoServiceConfig("services/api", `
+  build_filter {
+    paths         = ["services/api/**", "packages/shared/**"]
+    ignored_paths = ["**/*.md"]
+  }
+`)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("render_service.api", "root_directory", "services/api"),
+					resource.TestCheckResourceAttr("render_service.api", "build_filter.paths.#", "2"),
+					resource.TestCheckResourceAttr("render_service.api", "build_filter.paths.1", "packages/shared/**"),
+					resource.TestCheckResourceAttr("render_service.api", "build_filter.ignored_paths.0", "**/*.md"),
+					testAccCaptureID("render_service.api", "id", &id),
+				),
+			},
+			{
+				ResourceName:      "render_service.api",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccConfig(server, testAccMonorepoServiceConfig("services/web", "")),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("render_service.api", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPtr("render_service.api", "id", &id),
+					resource.TestCheckResourceAttr("render_service.api", "root_directory", "services/web"),
+					resource.TestCheckNoResourceAttr("render_service.api", "build_filter.paths.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccServiceResource_invalidRegionAndPlan(t *testing.T) {
+	server := newTestAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(server, `
+resource "render_service" "api" {
+  name = "api"
+  repo = "https://github.com/render-examples/express-hello-world"
+  type = "web_service"
+
+  web_service_details = {
+    env    = "node"
+    region = "london"
+  }
+}
+`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`region value must be one of:\s+\["frankfurt"\s+"ohio"\s+"oregon"\s+"singapore"\s+"virginia"\]`),
+			},
+			{
+				Config:      testAccConfig(server, testAccPrivateServiceConfig("db", "huge")),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`plan value must be one of`),
+			},
+		},
+	})
+}
+
+func testAccDefaultsConfig(server *fakerender.Server, region string) string {
+	return fmt.Sprintf(`
+provider "render" {
+  api_key = "rnd_test"
+  api_url = %q
+  email   = %q
+
+  defaults {
+    region      = "frankfurt"
+    plan        = "standard"
+    branch      = "develop"
+    auto_deploy = false
+  }
+}
+
+resource "render_service" "api" {
+  name = "api"
+  repo = "https://github.com/render-examples/express-hello-world"
+  type = "web_service"
+
+  web_service_details = {
+    env    = "node"
+    region = %s
+  }
+}
+`, server.URL, testAccOwnerEmail, region)
+}
+
+func TestAccServiceResource_providerDefaults(t *testing.T) {
+	server := newTestAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckServicesDestroyed(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDefaultsConfig(server, "null"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						expectPlannedValue{"render_service.api", tfjsonpath.New("branch"), "develop"},
+						expectPlannedValue{"render_service.api", tfjsonpath.New("auto_deploy"), false},
+						expectPlannedValue{"render_service.api", tfjsonpath.New("web_service_details").AtMapKey("region"), "frankfurt"},
+						expectPlannedValue{"render_service.api", tfjsonpath.New("web_service_details").AtMapKey("plan"), "standard"},
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("render_service.api", "branch", "develop"),
+					resource.TestCheckResourceAttr("render_service.api", "auto_deploy", "false"),
+					resource.TestCheckResourceAttr("render_service.api", "web_service_details.region", "frankfurt"),
+					resource.TestCheckResourceAttr("render_service.api", "web_service_details.plan", "standard"),
+				),
+			},
+			{
+				Config: testAccDefaultsConfig(server, `"singapore"`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("render_service.api", plancheck.ResourceActionReplace),
+						expectPlannedValue{"render_service.api", tfjsonpath.New("web_service_details").AtMapKey("region"), "singapore"},
+					},
+				},
+				Check: resource.TestCheckResourceAttr("render_service.api", "web_service_details.region", "singapore"),
+			},
+		},
+	})
+}
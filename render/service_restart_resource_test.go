@@ -0,0 +1,102 @@
+package render
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/jackall3n/terraform-provider-render/internal/fakerender"
+)
+
+func testAccServiceRestartConfig(secret string, clearCache bool) string {
+	return testAccWebServiceConfig("api", "https://github.com/render-examples/express-hello-world", "starter") + fmt.Sprintf(`
+resource "render_service_restart" "api" {
+  service_id  = render_service.api.id
+  clear_cache = %t
+
+  triggers = {
+    secret = %q
+  }
+}
+`, clearCache, secret)
+}
+
+// testAccCheckRestarts fails when the service wasn't restarted count times, or its cache cleared cacheClears times.
+func testAccCheckRestarts(server *fakerender.Server, serviceId *string, count int, cacheClears int) func(*terraform.State) error {
+	return func(*terraform.State) error {
+		if events := server.Events(*serviceId); len(events) != count {
+			return fmt.Errorf("expected %d restarts, got %d", count, len(events))
+		}
+
+		if clears := server.CacheClears(*serviceId); clears != cacheClears {
+			return fmt.Errorf("expected %d cache clears, got %d", cacheClears, clears)
+		}
+
+		return nil
+	}
+}
+
+func TestAccServiceRestartResource(t *testing.T) {
+	server := newTestAccServer(t)
+
+	var serviceId string
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckServicesDestroyed(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(server, testAccServiceRestartConfig("v1", false)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCaptureID("render_service.api", "id", &serviceId),
+					resource.TestCheckResourceAttrPair("render_service_restart.api", "id", "render_service.api", "id"),
+					resource.TestCheckNoResourceAttr("render_service_restart.api", "deploy_id"),
+					testAccCheckRestarts(server, &serviceId, 1, 0),
+				),
+			},
+			{
+				Config: testAccConfig(server, testAccServiceRestartConfig("v2", false)),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("render_service_restart.api", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: testAccCheckRestarts(server, &serviceId, 2, 0),
+			},
+			// Changing clear_cache alone doesn't restart the service
+			{
+				Config: testAccConfig(server, testAccServiceRestartConfig("v2", true)),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("render_service_restart.api", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: testAccCheckRestarts(server, &serviceId, 2, 0),
+			},
+			{
+				Config: testAccConfig(server, testAccServiceRestartConfig("v3", true)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("render_service_restart.api", "deploy_id"),
+					testAccCheckRestarts(server, &serviceId, 2, 1),
+				),
+			},
+			{
+				PreConfig:   func() { server.SetFailing(serviceId, true) },
+				Config:      testAccConfig(server, testAccServiceRestartConfig("v4", true)),
+				ExpectError: regexp.MustCompile(`ended\s+with\s+status\s+update_failed`),
+			},
+			{
+				Config:      testAccConfig(server, testAccServiceRestartConfig("v4", false)),
+				ExpectError: regexp.MustCompile(`failed\s+to\s+become\s+healthy`),
+			},
+			{
+				PreConfig: func() { server.SetFailing(serviceId, false) },
+				Config:    testAccConfig(server, testAccServiceRestartConfig("v4", false)),
+				Check:     testAccCheckRestarts(server, &serviceId, 4, 2),
+			},
+		},
+	})
+}
@@ -0,0 +1,136 @@
+package render
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/jackall3n/render-go"
+	"github.com/jackall3n/terraform-provider-render/internal/fakerender"
+)
+
+// testAccServiceRollbackConfig deploys the service again whenever release changes, and rolls it back to
+// rollbackTo unless it is empty.
+func testAccServiceRollbackConfig(release string, rollbackTo string) string {
+	config := testAccServiceRestartConfig(release, true)
+
+	if rollbackTo != "" {
+		config += fmt.Sprintf(`
+resource "render_service_rollback" "api" {
+  service_id = render_service.api.id
+  deploy_id  = %q
+
+  depends_on = [render_service_restart.api]
+}
+`, rollbackTo)
+	}
+
+	return config
+}
+
+// testAccCheckLiveDeploy fails when deployId isn't the only live deploy of the service.
+func testAccCheckLiveDeploy(server *fakerender.Server, deployId string) func(*terraform.State) error {
+	return func(s *terraform.State) error {
+		serviceId := s.RootModule().Resources["render_service.api"].Primary.Attributes["id"]
+
+		for _, deploy := range server.Deploys(serviceId) {
+			if live := *deploy.Status == render.Live; live != (deploy.Id == deployId) {
+				return fmt.Errorf("expected only %s to be live, %s is %s", deployId, deploy.Id, *deploy.Status)
+			}
+		}
+
+		return nil
+	}
+}
+
+func TestAccServiceRollbackResource(t *testing.T) {
+	server := newTestAccServer(t)
+
+	var serviceId string
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckServicesDestroyed(server),
+		Steps: []resource.TestStep{
+			// Creating the service deploys dep-00000001, the restart dep-00000002
+			{
+				Config: testAccConfig(server, testAccServiceRollbackConfig("v1", "")),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCaptureID("render_service.api", "id", &serviceId),
+					testAccCheckLiveDeploy(server, "dep-00000002"),
+				),
+			},
+			{
+				Config: testAccConfig(server, testAccServiceRollbackConfig("v1", "dep-00000001")),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("render_service_rollback.api", "id", "dep-00000003"),
+					resource.TestCheckResourceAttr("render_service_rollback.api", "status", "live"),
+					testAccCheckLiveDeploy(server, "dep-00000003"),
+				),
+			},
+			// A new deploy replaces the rollback, which is read back as deactivated
+			{
+				Config: testAccConfig(server, testAccServiceRollbackConfig("v2", "dep-00000001")),
+				Check:  testAccCheckLiveDeploy(server, "dep-00000004"),
+			},
+			{
+				Config: testAccConfig(server, testAccServiceRollbackConfig("v2", "dep-00000001")),
+				Check:  resource.TestCheckResourceAttr("render_service_rollback.api", "status", "deactivated"),
+			},
+			{
+				Config: testAccConfig(server, testAccServiceRollbackConfig("v2", "dep-00000002")),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("render_service_rollback.api", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("render_service_rollback.api", "id", "dep-00000005"),
+					resource.TestCheckResourceAttr("render_service_rollback.api", "status", "live"),
+					testAccCheckLiveDeploy(server, "dep-00000005"),
+				),
+			},
+			{
+				Config:      testAccConfig(server, testAccServiceRollbackConfig("v2", "dep-99999999")),
+				ExpectError: regexp.MustCompile(`failed\s+to\s+roll\s+back\s+service`),
+			},
+			{
+				PreConfig:   func() { server.SetFailing(serviceId, true) },
+				Config:      testAccConfig(server, testAccServiceRollbackConfig("v2", "dep-00000004")),
+				ExpectError: regexp.MustCompile(`ended\s+with\s+status\s+update_failed`),
+			},
+			{
+				PreConfig: func() { server.SetFailing(serviceId, false) },
+				Config:    testAccConfig(server, testAccServiceRollbackConfig("v2", "dep-00000004")),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("render_service_rollback.api", "id", "dep-00000007"),
+					testAccCheckLiveDeploy(server, "dep-00000007"),
+				),
+			},
+			// A rollback deploy Render no longer keeps stays in state, rolling back again would redeploy the service
+			{
+				PreConfig: func() { server.DeleteDeploy(serviceId, "dep-00000007") },
+				Config:    testAccConfig(server, testAccServiceRollbackConfig("v2", "dep-00000004")),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("render_service_rollback.api", "id", "dep-00000007"),
+					resource.TestCheckNoResourceAttr("render_service_rollback.api", "status"),
+					func(_ *terraform.State) error {
+						if deploys := len(server.Deploys(serviceId)); deploys != 6 {
+							return fmt.Errorf("expected no new deploy, got %d deploys", deploys)
+						}
+
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
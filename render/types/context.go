@@ -1,8 +1,21 @@
 package types
 
-import "github.com/jackall3n/render-go"
+import (
+	"github.com/jackall3n/render-go"
+	"github.com/jackall3n/terraform-provider-render/render/api"
+)
 
 type Context struct {
-	Client *render.ClientWithResponses
-	Owner  *render.Owner
+	Client   *render.ClientWithResponses
+	API      *api.Client
+	Owner    *render.Owner
+	Defaults Defaults
+}
+
+// Defaults are the values of the provider `defaults` block, empty when not set.
+type Defaults struct {
+	Region     string
+	Plan       string
+	Branch     string
+	AutoDeploy *bool
 }
@@ -0,0 +1,36 @@
+package utils
+
+// PageLimit is the page size of list requests, the largest the Render API allows.
+const PageLimit = 100
+
+// Page is a page of a list request. Size counts every entry of the page, including
+// those without an item, and Cursor is the cursor of its last entry.
+type Page[T any, C any] struct {
+	Items  []T
+	Size   int
+	Cursor *C
+}
+
+// Paginate collects the items of every page of a list request. fetch requests the page after
+// cursor, nil for the first page, with PageLimit as limit. Paging stops at the first page that
+// isn't full or has no cursor.
+func Paginate[T any, C any](fetch func(cursor *C) (Page[T, C], error)) ([]T, error) {
+	var items []T
+	var cursor *C
+
+	for {
+		page, err := fetch(cursor)
+
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, page.Items...)
+
+		if page.Size < PageLimit || page.Cursor == nil {
+			return items, nil
+		}
+
+		cursor = page.Cursor
+	}
+}
@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// pages returns a fetch function serving items in pages of PageLimit, with the index of
+// the last item as cursor.
+func pages(items int, requests *[]string) func(cursor *int) (Page[int, int], error) {
+	return func(cursor *int) (Page[int, int], error) {
+		var page Page[int, int]
+
+		start := 0
+
+		if cursor != nil {
+			start = *cursor + 1
+		}
+
+		*requests = append(*requests, fmt.Sprint(start))
+
+		for i := start; i < items && i < start+PageLimit; i++ {
+			index := i
+			page.Items = append(page.Items, i)
+			page.Cursor = &index
+		}
+
+		page.Size = len(page.Items)
+
+		return page, nil
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	for _, items := range []int{0, 3, PageLimit, 2*PageLimit + 3} {
+		var requests []string
+
+		result, err := Paginate(pages(items, &requests))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(result) != items {
+			t.Errorf("expected %d items, got %d", items, len(result))
+		}
+
+		// A full last page needs one more request to find out there is nothing after it
+		if expected := items/PageLimit + 1; len(requests) != expected {
+			t.Errorf("expected %d requests for %d items, got %v", expected, items, requests)
+		}
+	}
+}
+
+func TestPaginateStopsWithoutCursor(t *testing.T) {
+	requests := 0
+
+	result, err := Paginate(func(cursor *int) (Page[int, int], error) {
+		requests++
+
+		return Page[int, int]{Items: make([]int, PageLimit), Size: PageLimit}, nil
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if requests != 1 || len(result) != PageLimit {
+		t.Errorf("expected a single page, got %d items in %d requests", len(result), requests)
+	}
+}
+
+func TestPaginateError(t *testing.T) {
+	failure := errors.New("500 Internal Server Error")
+
+	if _, err := Paginate(func(cursor *int) (Page[int, int], error) { return Page[int, int]{}, failure }); err != failure {
+		t.Errorf("expected %v, got %v", failure, err)
+	}
+}
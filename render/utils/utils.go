@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"github.com/jackall3n/render-go"
 	"reflect"
+	"sort"
+	"strings"
 )
 
 func GetBlock(value interface{}) map[string]interface{} {
@@ -58,10 +60,72 @@ var (
 
 	regionMap = map[string]render.Region{
 		"frankfurt": render.Frankfurt,
+		"ohio":      render.Region("ohio"),
 		"oregon":    render.Oregon,
+		"singapore": render.Region("singapore"),
+		"virginia":  render.Region("virginia"),
+	}
+
+	regionNames = map[string]string{
+		"frankfurt": "Frankfurt (EU Central)",
+		"ohio":      "Ohio (US East)",
+		"oregon":    "Oregon (US West)",
+		"singapore": "Singapore (Southeast Asia)",
+		"virginia":  "Virginia (US East)",
+	}
+
+	// plans lists the instance types of services, from the smallest to the largest.
+	plans = []string{
+		"free",
+		"starter",
+		"starter_plus",
+		"standard",
+		"standard_plus",
+		"pro",
+		"pro_plus",
+		"pro_max",
+		"pro_ultra",
 	}
 )
 
+// Regions returns the IDs of every Render region, sorted.
+func Regions() []string {
+	regions := make([]string, 0, len(regionMap))
+
+	for region := range regionMap {
+		regions = append(regions, region)
+	}
+
+	sort.Strings(regions)
+
+	return regions
+}
+
+// RegionName returns the display name of a region, e.g. `Oregon (US West)`.
+func RegionName(region string) string {
+	return regionNames[region]
+}
+
+// Join formats values as a list of code spans, e.g. "`a`, `b` or `c`".
+func Join(values []string) string {
+	quoted := make([]string, len(values))
+
+	for i, value := range values {
+		quoted[i] = "`" + value + "`"
+	}
+
+	if len(quoted) < 2 {
+		return strings.Join(quoted, "")
+	}
+
+	return strings.Join(quoted[:len(quoted)-1], ", ") + " or " + quoted[len(quoted)-1]
+}
+
+// Plans returns every plan tier of services, from the smallest to the largest.
+func Plans() []string {
+	return append([]string{}, plans...)
+}
+
 func ToJson(value interface{}) map[string]interface{} {
 	b, _ := json.Marshal(&value)
 	var m map[string]interface{}
@@ -96,13 +160,20 @@ func ToYesNo(value interface{}) *YesNo {
 }
 
 func Struct(input interface{}, output interface{}) error {
-	jsonString, _ := json.Marshal(input)
-
-	err := json.Unmarshal(jsonString, output)
+	jsonString, err := json.Marshal(input)
 
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return json.Unmarshal(jsonString, output)
+}
+
+// SameRepo compares repository URLs, ignoring case and a trailing `/` or `.git`.
+func SameRepo(a string, b string) bool {
+	normalize := func(repo string) string {
+		return strings.TrimSuffix(strings.TrimSuffix(strings.ToLower(repo), "/"), ".git")
+	}
+
+	return normalize(a) == normalize(b)
 }
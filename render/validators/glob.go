@@ -0,0 +1,60 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// Glob validates that a string is a glob pattern relative to the repository root, e.g. `src/**/*.go`.
+func Glob() validator.String {
+	return &globValidator{}
+}
+
+type globValidator struct{}
+
+var _ validator.String = (*globValidator)(nil)
+
+func (v *globValidator) Description(ctx context.Context) string {
+	return "value must be a glob pattern relative to the repository root"
+}
+
+func (v *globValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v *globValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	pattern := req.ConfigValue.ValueString()
+
+	if err := checkGlob(pattern); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid glob pattern",
+			fmt.Sprintf("%q is not a valid glob pattern: %s", pattern, err.Error()),
+		)
+	}
+}
+
+func checkGlob(pattern string) error {
+	if strings.TrimSpace(pattern) == "" {
+		return fmt.Errorf("the pattern is empty")
+	}
+
+	if strings.HasPrefix(pattern, "/") {
+		return fmt.Errorf("the pattern must be relative to the repository root")
+	}
+
+	// Match reports a malformed pattern, e.g. an unclosed `[`, regardless of the name it is matched against.
+	if _, err := path.Match(pattern, ""); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,17 @@
+package validators
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/jackall3n/terraform-provider-render/render/utils"
+)
+
+// Region validates that a string is one of the regions in utils.Regions.
+func Region() validator.String {
+	return stringvalidator.OneOf(utils.Regions()...)
+}
+
+// Plan validates that a string is one of the plans in utils.Plans.
+func Plan() validator.String {
+	return stringvalidator.OneOf(utils.Plans()...)
+}